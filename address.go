@@ -0,0 +1,418 @@
+package workemailvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxLocalPartLength is the maximum length of the local part in octets (RFC 5321 section 4.5.3.1.1).
+	maxLocalPartLength = 64
+	// maxAddressLength is the maximum length of an address in octets (RFC 5321 path limit minus the angle brackets).
+	maxAddressLength = 254
+	// maxLabelLength is the maximum length of a domain label in octets (RFC 1035 section 2.3.4).
+	maxLabelLength = 63
+)
+
+// ErrInvalidAddress is wrapped by every error returned from ParseAddress.
+var ErrInvalidAddress = errors.New("invalid email address")
+
+// Address is an email address parsed by ParseAddress.
+type Address struct {
+	// Name is the decoded display name, or empty if the address had none.
+	Name string
+	// Local is the local part as written, with comments and folding whitespace removed.
+	// A quoted local part keeps its quotes and escapes, e.g. `"a@b"`.
+	Local string
+	// Domain is the domain as written, with comments and folding whitespace removed. It is not normalized.
+	Domain string
+}
+
+// String returns the address in addr-spec form: local@domain.
+func (a Address) String() string {
+	return a.Local + "@" + a.Domain
+}
+
+// ParseAddress parses a single RFC 5322 address, either a bare addr-spec ("jane@corp.com") or a name-addr
+// ("Jane Doe <jane@corp.com>"). Comments and folding whitespace are allowed where RFC 5322 allows CFWS,
+// local parts may be dot-atoms or quoted strings, and UTF-8 is accepted in local parts, domains and
+// display names as in RFC 6531 (SMTPUTF8). The local part is limited to 64 octets and the address to 254.
+// Domain literals such as "[192.0.2.1]" are rejected.
+func ParseAddress(address string) (Address, error) {
+	if !utf8.ValidString(address) {
+		return Address{}, addressError("invalid UTF-8")
+	}
+
+	parser := &addressParser{input: address, pos: 0}
+
+	return parser.parse()
+}
+
+// addressError returns an error wrapping ErrInvalidAddress with the given detail.
+func addressError(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidAddress, fmt.Sprintf(format, args...))
+}
+
+// addressParser is a recursive-descent parser over an address string.
+type addressParser struct {
+	input string
+	pos   int
+}
+
+func (p *addressParser) parse() (Address, error) {
+	if err := p.skipCFWS(); err != nil {
+		return Address{}, err
+	}
+
+	if p.pos == len(p.input) {
+		return Address{}, addressError("empty address")
+	}
+
+	var (
+		address Address
+		err     error
+	)
+
+	if p.hasAngleAddr() {
+		address, err = p.parseNameAddr()
+	} else {
+		address, err = p.parseAddrSpec()
+	}
+
+	if err != nil {
+		return Address{}, err
+	}
+
+	if p.pos != len(p.input) {
+		return Address{}, addressError("unexpected %q after address", p.input[p.pos:])
+	}
+
+	if len(address.Local) > maxLocalPartLength {
+		return Address{}, addressError("local part exceeds %d octets", maxLocalPartLength)
+	}
+
+	if len(address.Local)+1+len(address.Domain) > maxAddressLength {
+		return Address{}, addressError("address exceeds %d octets", maxAddressLength)
+	}
+
+	return address, nil
+}
+
+// hasAngleAddr reports whether a '<' appears outside quoted strings and comments.
+func (p *addressParser) hasAngleAddr() bool {
+	depth := 0
+	quoted := false
+
+	for i := p.pos; i < len(p.input); i++ {
+		switch char := p.input[i]; {
+		case char == '\\':
+			i++
+		case quoted:
+			quoted = char != '"'
+		case char == '"' && depth == 0:
+			quoted = true
+		case char == '(':
+			depth++
+		case char == ')' && depth > 0:
+			depth--
+		case char == '<' && depth == 0:
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseNameAddr parses [display-name] "<" addr-spec ">" [CFWS].
+func (p *addressParser) parseNameAddr() (Address, error) {
+	name, err := p.parsePhrase()
+	if err != nil {
+		return Address{}, err
+	}
+
+	if !p.consume('<') {
+		return Address{}, addressError("unexpected %q in display name", p.input[p.pos:p.pos+1])
+	}
+
+	address, err := p.parseAddrSpec()
+	if err != nil {
+		return Address{}, err
+	}
+
+	if !p.consume('>') {
+		return Address{}, addressError("missing closing '>'")
+	}
+
+	if err := p.skipCFWS(); err != nil {
+		return Address{}, err
+	}
+
+	address.Name = name
+
+	return address, nil
+}
+
+// parsePhrase parses the display name: words (atoms or quoted strings) separated by CFWS.
+// Dots are allowed in atoms as in the obsolete phrase syntax ("John Q. Public").
+func (p *addressParser) parsePhrase() (string, error) {
+	var words []string
+
+	for {
+		if err := p.skipCFWS(); err != nil {
+			return "", err
+		}
+
+		switch {
+		case p.peek() == '"':
+			word, err := p.parseQuotedString()
+			if err != nil {
+				return "", err
+			}
+
+			words = append(words, unquote(word))
+		case p.pos < len(p.input) && (isAtext(p.input[p.pos]) || p.input[p.pos] == '.'):
+			start := p.pos
+			for p.pos < len(p.input) && (isAtext(p.input[p.pos]) || p.input[p.pos] == '.') {
+				p.pos++
+			}
+
+			words = append(words, p.input[start:p.pos])
+		default:
+			return strings.Join(words, " "), nil
+		}
+	}
+}
+
+// parseAddrSpec parses local-part "@" domain, each surrounded by optional CFWS.
+func (p *addressParser) parseAddrSpec() (Address, error) {
+	local, err := p.parseLocalPart()
+	if err != nil {
+		return Address{}, err
+	}
+
+	if !p.consume('@') {
+		if p.pos == len(p.input) {
+			return Address{}, addressError("missing '@'")
+		}
+
+		return Address{}, addressError("unexpected %q in local part", p.input[p.pos:p.pos+1])
+	}
+
+	domain, err := p.parseDomain()
+	if err != nil {
+		return Address{}, err
+	}
+
+	return Address{Name: "", Local: local, Domain: domain}, nil
+}
+
+// parseLocalPart parses [CFWS] (dot-atom / quoted-string) [CFWS].
+func (p *addressParser) parseLocalPart() (string, error) {
+	if err := p.skipCFWS(); err != nil {
+		return "", err
+	}
+
+	var (
+		local string
+		err   error
+	)
+
+	if p.peek() == '"' {
+		local, err = p.parseQuotedString()
+	} else {
+		local, err = p.parseDotAtom("local part")
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return local, p.skipCFWS()
+}
+
+// parseDomain parses [CFWS] dot-atom [CFWS] and checks the labels are valid host name labels.
+func (p *addressParser) parseDomain() (string, error) {
+	if err := p.skipCFWS(); err != nil {
+		return "", err
+	}
+
+	if p.peek() == '[' {
+		return "", addressError("domain literals are not supported")
+	}
+
+	domain, err := p.parseDotAtom("domain")
+	if err != nil {
+		return "", err
+	}
+
+	for label := range strings.SplitSeq(domain, ".") {
+		if len(label) > maxLabelLength {
+			return "", addressError("domain label %q exceeds %d octets", label, maxLabelLength)
+		}
+
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return "", addressError("domain label %q starts or ends with a hyphen", label)
+		}
+
+		for i := range len(label) {
+			if char := label[i]; char < utf8.RuneSelf && !isLetterDigitHyphen(char) {
+				return "", addressError("invalid character %q in domain", char)
+			}
+		}
+	}
+
+	return domain, p.skipCFWS()
+}
+
+// parseDotAtom parses 1*atext *("." 1*atext). The part names the address part for error messages.
+func (p *addressParser) parseDotAtom(part string) (string, error) {
+	start := p.pos
+
+	for p.pos < len(p.input) && (isAtext(p.input[p.pos]) || p.input[p.pos] == '.') {
+		p.pos++
+	}
+
+	atom := p.input[start:p.pos]
+
+	switch {
+	case atom == "":
+		return "", addressError("missing %s", part)
+	case atom[0] == '.':
+		return "", addressError("%s starts with a dot", part)
+	case atom[len(atom)-1] == '.':
+		return "", addressError("%s ends with a dot", part)
+	case strings.Contains(atom, ".."):
+		return "", addressError("%s contains consecutive dots", part)
+	}
+
+	return atom, nil
+}
+
+// parseQuotedString parses a quoted string and returns it as written, including the quotes.
+func (p *addressParser) parseQuotedString() (string, error) {
+	start := p.pos
+	p.pos++ // opening quote
+
+	for p.pos < len(p.input) {
+		switch char := p.input[p.pos]; {
+		case char == '"':
+			p.pos++
+			return p.input[start:p.pos], nil
+		case char == '\\':
+			if p.pos+1 == len(p.input) || !isQuotedPairChar(p.input[p.pos+1]) {
+				return "", addressError("invalid escape in quoted string")
+			}
+
+			p.pos += 2
+		case char < ' ' && char != '\t' || char == 127:
+			return "", addressError("control character in quoted string")
+		default:
+			p.pos++
+		}
+	}
+
+	return "", addressError("unterminated quoted string")
+}
+
+// skipCFWS skips folding whitespace and (possibly nested) comments.
+func (p *addressParser) skipCFWS() error {
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case ' ', '\t', '\r', '\n':
+			p.pos++
+		case '(':
+			if err := p.skipComment(); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// skipComment skips a comment, which may contain nested comments and quoted pairs.
+func (p *addressParser) skipComment() error {
+	depth := 0
+
+	for p.pos < len(p.input) {
+		switch p.input[p.pos] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				p.pos++
+				return nil
+			}
+		case '\\':
+			p.pos++
+		}
+
+		p.pos++
+	}
+
+	return addressError("unterminated comment")
+}
+
+// peek returns the current byte, or 0 at the end of input.
+func (p *addressParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+
+	return 0
+}
+
+// consume advances past the expected byte and reports whether it was present.
+func (p *addressParser) consume(expected byte) bool {
+	if p.peek() != expected {
+		return false
+	}
+
+	p.pos++
+
+	return true
+}
+
+// unquote removes the surrounding quotes and backslash escapes from a quoted string.
+func unquote(quoted string) string {
+	inner := quoted[1 : len(quoted)-1]
+	if !strings.Contains(inner, `\`) {
+		return inner
+	}
+
+	var builder strings.Builder
+
+	for i := 0; i < len(inner); i++ {
+		if inner[i] == '\\' {
+			i++
+		}
+
+		builder.WriteByte(inner[i])
+	}
+
+	return builder.String()
+}
+
+// isAtext reports whether the byte may appear in an atom (RFC 5322 atext, extended with UTF-8 by RFC 6531).
+func isAtext(char byte) bool {
+	if char >= utf8.RuneSelf || isLetterDigitHyphen(char) {
+		return true
+	}
+
+	return strings.IndexByte("!#$%&'*+/=?^_`{|}~", char) >= 0
+}
+
+// isLetterDigitHyphen reports whether the byte is an ASCII letter, digit or hyphen.
+func isLetterDigitHyphen(char byte) bool {
+	return 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z' || '0' <= char && char <= '9' || char == '-'
+}
+
+// isQuotedPairChar reports whether the byte may follow a backslash in a quoted pair (VCHAR, WSP or UTF-8).
+func isQuotedPairChar(char byte) bool {
+	return char == ' ' || char == '\t' || char > ' ' && char != 127
+}
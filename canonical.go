@@ -0,0 +1,171 @@
+package workemailvalidator
+
+import (
+	"strings"
+)
+
+// CanonicalRule describes how a mailbox provider maps address variants to the same mailbox.
+type CanonicalRule struct {
+	// Domains are the domains the rule applies to.
+	Domains []string
+	// Canonical is the domain all Domains are rewritten to, or empty to keep the domain.
+	Canonical string
+	// TagSeparators are the characters that start a subaddress tag, which is removed with the separator,
+	// e.g. "+" for "jane+news". An empty string keeps tags.
+	TagSeparators string
+	// IgnoredChars are removed from the local part, e.g. "." for Gmail, where "j.a.n.e" is "jane".
+	IgnoredChars string
+}
+
+// defaultCanonicalRule applies to domains without a rule: "+" tags (RFC 5233) are removed.
+var defaultCanonicalRule = CanonicalRule{Domains: nil, Canonical: "", TagSeparators: "+", IgnoredChars: ""}
+
+// canonicalRules are the provider rules applied by Canonicalize.
+var canonicalRules = []CanonicalRule{
+	{
+		Domains:       []string{"gmail.com", "googlemail.com"},
+		Canonical:     "gmail.com",
+		TagSeparators: "+",
+		IgnoredChars:  ".",
+	},
+	{
+		// Outlook.com treats dots as significant and keeps each domain a separate namespace.
+		Domains:       []string{"outlook.com", "hotmail.com", "live.com", "msn.com", "hotmail.co.uk", "outlook.fr", "hotmail.fr", "live.co.uk"},
+		Canonical:     "",
+		TagSeparators: "+",
+		IgnoredChars:  "",
+	},
+	{
+		// Yahoo disposable addresses are "<base>-<keyword>".
+		Domains:       []string{"yahoo.com", "ymail.com", "rocketmail.com", "yahoo.co.uk", "yahoo.fr", "yahoo.de"},
+		Canonical:     "",
+		TagSeparators: "-",
+		IgnoredChars:  "",
+	},
+	{
+		Domains:       []string{"icloud.com", "me.com", "mac.com"},
+		Canonical:     "icloud.com",
+		TagSeparators: "+",
+		IgnoredChars:  "",
+	},
+	{
+		// Proton usernames are unique across its domains and ignore dots, hyphens and underscores.
+		Domains:       []string{"proton.me", "protonmail.com", "protonmail.ch", "pm.me"},
+		Canonical:     "proton.me",
+		TagSeparators: "+",
+		IgnoredChars:  ".-_",
+	},
+	{
+		Domains:       []string{"fastmail.com", "fastmail.fm"},
+		Canonical:     "",
+		TagSeparators: "+",
+		IgnoredChars:  "",
+	},
+}
+
+// newCanonicalRuleSet indexes rules by normalized domain. Later rules override earlier ones for the same domain.
+func newCanonicalRuleSet(rules []CanonicalRule) map[string]CanonicalRule {
+	set := make(map[string]CanonicalRule)
+	addCanonicalRules(set, rules)
+
+	return set
+}
+
+// addCanonicalRules adds rules to the set with their domains normalized.
+func addCanonicalRules(set map[string]CanonicalRule, rules []CanonicalRule) {
+	for _, rule := range rules {
+		rule.Canonical = normalize(rule.Canonical)
+
+		for _, domain := range rule.Domains {
+			if domain = normalize(domain); domain != "" {
+				set[domain] = rule
+			}
+		}
+	}
+}
+
+// Canonicalize returns the canonical form of the email address, so addresses that reach the same mailbox
+// compare equal: "Jane.Doe+trial@googlemail.com" becomes "janedoe@gmail.com". Providers are matched by
+// the rules table (subaddress tags, ignored dots, domain aliases); other domains only have "+" tags removed.
+// The result is lowercase, with the domain in ASCII and without the display name. It is meant for
+// deduplication, not for sending mail. Use WithCanonicalRules to add providers.
+func (v *Validator) Canonicalize(email string) (string, error) {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return "", err
+	}
+
+	domain, err := v.NormalizeDomain(address.Domain)
+	if err != nil {
+		return "", err
+	}
+
+	rule, ok := v.canonicalRules[domain]
+	if !ok {
+		rule = defaultCanonicalRule
+	}
+
+	if rule.Canonical != "" {
+		domain = rule.Canonical
+	}
+
+	return canonicalLocalPart(address.Local, rule) + "@" + domain, nil
+}
+
+// canonicalLocalPart lowercases the local part, removes the subaddress tag and the ignored characters.
+// Quoted local parts are unquoted when the content is a plain dot-atom. A tag at the very start is kept,
+// and the local part is kept as is if nothing would remain.
+func canonicalLocalPart(local string, rule CanonicalRule) string {
+	if strings.HasPrefix(local, `"`) {
+		unquoted := unquote(local)
+		if !isDotAtom(unquoted) {
+			return local
+		}
+
+		local = unquoted
+	}
+
+	local = strings.ToLower(local)
+
+	if i := strings.IndexAny(local, rule.TagSeparators); i > 0 {
+		local = local[:i]
+	}
+
+	if rule.IgnoredChars == "" {
+		return local
+	}
+
+	stripped := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(rule.IgnoredChars, r) {
+			return -1
+		}
+
+		return r
+	}, local)
+
+	if stripped == "" {
+		return local
+	}
+
+	return stripped
+}
+
+// isDotAtom reports whether the string is a valid unquoted local part.
+func isDotAtom(local string) bool {
+	if local == "" || local[0] == '.' || local[len(local)-1] == '.' || strings.Contains(local, "..") {
+		return false
+	}
+
+	for i := range len(local) {
+		if !isAtext(local[i]) && local[i] != '.' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Canonicalize returns the canonical form of the email address for deduplication.
+func Canonicalize(email string) (string, error) {
+	return defaultValidator.Canonicalize(email)
+}
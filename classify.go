@@ -0,0 +1,67 @@
+package workemailvalidator
+
+// Category is the classification of a domain or email address.
+type Category int
+
+const (
+	// CategoryInvalid means the input is not a syntactically valid domain or email address.
+	CategoryInvalid Category = iota
+	// CategoryDisposable means the domain belongs to a disposable/temporary email service.
+	CategoryDisposable
+	// CategoryFree means the domain belongs to a free email provider.
+	CategoryFree
+	// CategoryBusiness means the domain is valid and neither disposable nor free.
+	CategoryBusiness
+	// CategoryNoMail means the domain would be business but does not accept mail.
+	// It is only reported by the context-aware methods of a validator built with WithMXCheck.
+	CategoryNoMail
+	// CategoryRelay means the domain belongs to an email relay/privacy-alias service that forwards to a real mailbox.
+	CategoryRelay
+)
+
+// String returns the lowercase name of the category.
+func (c Category) String() string {
+	switch c {
+	case CategoryInvalid:
+		return "invalid"
+	case CategoryDisposable:
+		return "disposable"
+	case CategoryFree:
+		return "free"
+	case CategoryBusiness:
+		return "business"
+	case CategoryNoMail:
+		return "no_mail"
+	case CategoryRelay:
+		return "relay"
+	default:
+		return "unknown"
+	}
+}
+
+// Classify returns the single category of the given domain.
+// A domain listed as both disposable and free is reported as disposable, and relay services are reported as
+// relays even if a disposable feed lists them.
+func (v *Validator) Classify(domain string) Category {
+	return v.category(domain)
+}
+
+// ClassifyEmail returns the category of the domain of the given email address.
+func (v *Validator) ClassifyEmail(email string) Category {
+	domain, ok := emailDomain(email)
+	if !ok {
+		return CategoryInvalid
+	}
+
+	return v.Classify(domain)
+}
+
+// Classify returns the single category of the given domain.
+func Classify(domain string) Category {
+	return defaultValidator.Classify(domain)
+}
+
+// ClassifyEmail returns the category of the domain of the given email address.
+func ClassifyEmail(email string) Category {
+	return defaultValidator.ClassifyEmail(email)
+}
@@ -0,0 +1,214 @@
+package workemailvalidator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrConfusable means the domain imitates a free or disposable domain with look-alike Unicode characters. Its
+// errors also match the sentinel of the imitated domain's category, ErrFreeProvider or ErrDisposable.
+var ErrConfusable = errors.New("confusable with a known domain")
+
+// confusableIndex maps the skeletons of the free and disposable domains back to the domains. It is built on
+// the first lookup of an internationalized domain, since ASCII domains never need it.
+type confusableIndex struct {
+	once      sync.Once
+	skeletons map[string]string
+}
+
+// confusableTable maps characters to the prototype they are confusable with. ASCII characters, which make up
+// nearly all of every domain, are kept in an array to keep skeletons cheap.
+type confusableTable struct {
+	ascii [utf8.RuneSelf]string
+	runes map[rune]string
+}
+
+// prototype returns the prototype of the character, and false if the character is its own prototype.
+func (t *confusableTable) prototype(char rune) (string, bool) {
+	if char < utf8.RuneSelf {
+		return t.ascii[char], t.ascii[char] != ""
+	}
+
+	prototype, ok := t.runes[char]
+
+	return prototype, ok
+}
+
+// loadConfusables parses the Unicode confusables.txt format: "<source> ; <prototype> ; <type> # comment" lines,
+// with space-separated hexadecimal code points and an optional byte order mark. Lines with a malformed or
+// multi-character source are skipped.
+func loadConfusables(data string) *confusableTable {
+	confusables := &confusableTable{ascii: [utf8.RuneSelf]string{}, runes: make(map[rune]string)}
+
+	for line := range strings.Lines(strings.TrimPrefix(data, "\ufeff")) {
+		line, _, _ = strings.Cut(line, "#")
+
+		fields := strings.Split(line, ";")
+		if len(fields) < 2 { //nolint:mnd // source and prototype
+			continue
+		}
+
+		source, sourceOK := parseCodePoints(fields[0])
+		prototype, prototypeOK := parseCodePoints(fields[1])
+
+		runes := []rune(source)
+
+		switch {
+		case !sourceOK || !prototypeOK || len(runes) != 1:
+			continue
+		case runes[0] < utf8.RuneSelf:
+			confusables.ascii[runes[0]] = prototype
+		default:
+			confusables.runes[runes[0]] = prototype
+		}
+	}
+
+	return confusables
+}
+
+// parseCodePoints decodes space-separated hexadecimal code points such as "0072 006E" into a string.
+func parseCodePoints(field string) (string, bool) {
+	var builder strings.Builder
+
+	for hex := range strings.FieldsSeq(field) {
+		codePoint, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || codePoint > unicode.MaxRune {
+			return "", false
+		}
+
+		builder.WriteRune(rune(codePoint))
+	}
+
+	return builder.String(), builder.Len() > 0
+}
+
+// skeleton returns the UTS #39 skeleton of the string: its NFD form with every character replaced by the
+// prototype it is confusable with, normalized again. Two strings are confusable if their skeletons are equal.
+// Invisible characters such as zero-width joiners are dropped.
+func skeleton(s string) string {
+	var builder strings.Builder
+
+	builder.Grow(len(s))
+
+	for _, char := range norm.NFD.String(s) {
+		if !unicode.IsGraphic(char) {
+			continue
+		}
+
+		if prototype, ok := confusablePrototypes.prototype(char); ok {
+			builder.WriteString(prototype)
+		} else {
+			builder.WriteRune(char)
+		}
+	}
+
+	return norm.NFD.String(builder.String())
+}
+
+// domainSkeleton returns the skeleton of a normalized domain, with Punycode labels decoded first.
+func domainSkeleton(domain string) string {
+	if strings.Contains(domain, "xn--") {
+		if unicodeDomain, err := idna.Punycode.ToUnicode(domain); err == nil {
+			domain = unicodeDomain
+		}
+	}
+
+	return skeleton(domain)
+}
+
+// confusableSkeletons returns the index of the validator's free and disposable domains by skeleton. A zero
+// Validator has no index, and no lists to imitate either.
+func (v *Validator) confusableSkeletons() map[string]string {
+	if v.confusables == nil {
+		return nil
+	}
+
+	v.confusables.once.Do(func() {
+		index := make(map[string]string, len(v.disposableDomains)+len(v.freeDomains))
+
+		for _, domains := range []domainSet{v.disposableDomains, v.freeDomains} {
+			for domain := range domains {
+				if !domains.listed(domain) {
+					continue
+				}
+
+				key := domainSkeleton(domain)
+				if existing, ok := index[key]; !ok || v.preferredTarget(domain, existing) {
+					index[key] = domain
+				}
+			}
+		}
+
+		v.confusables.skeletons = index
+	})
+
+	return v.confusables.skeletons
+}
+
+// preferredTarget reports whether domain a is a better answer than b for a shared skeleton. Free domains win,
+// since disposable feeds list look-alikes of popular providers such as "gmai1.com"; then the shorter and the
+// lexically smaller domain win, so "mailinator.com" beats "rnailinator.com".
+func (v *Validator) preferredTarget(a, b string) bool {
+	aFree, bFree := v.freeDomains.listed(a), v.freeDomains.listed(b)
+
+	switch {
+	case aFree != bFree:
+		return aFree
+	case len(a) != len(b):
+		return len(a) < len(b)
+	default:
+		return a < b
+	}
+}
+
+// confusable returns the free or disposable domain that a normalized internationalized domain imitates, its
+// category and the number of labels stripped to reach it. It walks the skeleton of the domain and its parents,
+// so "mail.gm\u0430il.com" (with a Cyrillic "a") imitates "gmail.com". ASCII domains are never reported: they
+// are matched against the lists directly.
+func (v *Validator) confusable(domain string) (string, Category, int, bool) {
+	if !strings.Contains(domain, "xn--") {
+		return "", CategoryInvalid, 0, false
+	}
+
+	index := v.confusableSkeletons()
+	depth := 0
+
+	for suffix := range suffixes(domainSkeleton(domain)) {
+		if match, ok := index[suffix]; ok && match != domain {
+			if v.disposableDomains.listed(match) {
+				return match, CategoryDisposable, depth, true
+			}
+
+			return match, CategoryFree, depth, true
+		}
+
+		depth++
+	}
+
+	return "", CategoryInvalid, 0, false
+}
+
+// ConfusableWith returns the free or disposable domain that the internationalized domain imitates with look-alike
+// characters, as defined by Unicode TR39 skeletons, and reports whether it found one. For example
+// "gm\u0430il.com", spelled with a Cyrillic "a", returns "gmail.com". Allowlisted domains, listed domains and
+// ASCII domains return false.
+func (v *Validator) ConfusableWith(domain string) (string, bool) {
+	result := v.Explain(domain)
+	if result.List != ListConfusable {
+		return "", false
+	}
+
+	return result.Match, true
+}
+
+// ConfusableWith returns the free or disposable domain that the internationalized domain imitates.
+func ConfusableWith(domain string) (string, bool) {
+	return defaultValidator.ConfusableWith(domain)
+}
@@ -0,0 +1,131 @@
+package workemailvalidator
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"strings"
+)
+
+// ErrDisposableMX means the domain's mail servers belong to a disposable email service. Its errors also match
+// ErrDisposable.
+var ErrDisposableMX = errors.New("disposable mail infrastructure")
+
+// mxRules matches MX hosts and their addresses against known disposable mail infrastructure.
+type mxRules struct {
+	hosts    map[string]struct{}
+	prefixes []netip.Prefix
+}
+
+// loadMXRules parses host names, IP addresses and CIDR ranges, one per line.
+func loadMXRules(data string) mxRules {
+	var entries []string
+
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entries = append(entries, line)
+	}
+
+	return newMXRules(entries)
+}
+
+// newMXRules sorts the entries into host names and address prefixes. Single addresses become full-length prefixes.
+func newMXRules(entries []string) mxRules {
+	rules := mxRules{hosts: make(map[string]struct{}), prefixes: nil}
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+
+		if prefix, err := netip.ParsePrefix(entry); err == nil {
+			rules.prefixes = append(rules.prefixes, prefix.Masked())
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(entry); err == nil {
+			rules.prefixes = append(rules.prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		if host := normalize(entry); host != "" {
+			rules.hosts[host] = struct{}{}
+		}
+	}
+
+	return rules
+}
+
+// matchDisposableMX checks the MX hosts against the disposable infrastructure rules and the disposable
+// domain list, then, if address rules exist, their resolved addresses. It returns the matched entry.
+func (v *Validator) matchDisposableMX(ctx context.Context, hosts []string) (string, bool) {
+	for _, host := range hosts {
+		_, relayDepth, relay := v.relayDomains.match(host)
+		disposableMatch, disposableDepth, disposable := v.disposableDomains.match(host)
+		depth := 0
+
+		for suffix := range suffixes(host) {
+			if _, ok := v.disposableMX.hosts[suffix]; ok {
+				return suffix, true
+			}
+
+			// Custom domains on a relay service's mail servers are relays, even if a disposable feed lists the service.
+			if relay && depth == relayDepth {
+				break
+			}
+
+			if disposable && depth == disposableDepth {
+				return disposableMatch, true
+			}
+
+			depth++
+		}
+	}
+
+	if len(v.disposableMX.prefixes) == 0 {
+		return "", false
+	}
+
+	for _, host := range hosts {
+		addresses, err := v.resolver().LookupHost(ctx, host)
+		if err != nil {
+			continue
+		}
+
+		for _, address := range addresses {
+			addr, err := netip.ParseAddr(address)
+			if err != nil {
+				continue
+			}
+
+			for _, prefix := range v.disposableMX.prefixes {
+				if prefix.Contains(addr.Unmap()) {
+					return prefix.String(), true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// IsDisposableMX resolves the MX hosts of the domain and reports whether they belong to known disposable
+// mail infrastructure. It returns an error if the MX lookup fails.
+func (v *Validator) IsDisposableMX(ctx context.Context, domain string) (bool, error) {
+	mx, err := v.LookupMX(ctx, domain)
+	if err != nil {
+		return false, err
+	}
+
+	_, ok := v.matchDisposableMX(ctx, mx.Hosts)
+
+	return ok, nil
+}
+
+// IsDisposableMX reports whether the domain's MX hosts belong to known disposable mail infrastructure,
+// using the system resolver.
+func IsDisposableMX(ctx context.Context, domain string) (bool, error) {
+	return defaultValidator.IsDisposableMX(ctx, domain)
+}
@@ -0,0 +1,133 @@
+package workemailvalidator
+
+import "strings"
+
+// entryScope is the set of names a list entry covers or exempts, relative to its domain.
+type entryScope uint8
+
+const (
+	// scopeDomain covers the domain itself, as in "=example.com".
+	scopeDomain entryScope = 1 << iota
+	// scopeSubdomains covers the subdomains of the domain, as in "*.example.com".
+	scopeSubdomains
+	// exemptDomain exempts the domain itself from the entries of its parents, as in "!=example.com".
+	exemptDomain
+	// exemptSubdomains exempts the subdomains of the domain from the entries of its parents, as in "!*.example.com".
+	exemptSubdomains
+
+	// scopeTree covers the domain and its subdomains, the scope of a plain entry such as "example.com".
+	scopeTree = scopeDomain | scopeSubdomains
+	// exemptShift turns covering scopes into the matching exemptions.
+	exemptShift = 2
+)
+
+// domainSet is a domain list: the domains of its entries mapped to the names the entries cover or exempt.
+type domainSet map[string]entryScope
+
+// splitEntry splits a list entry into its marker, e.g. "!", "=" or "!*.", and its domain.
+func splitEntry(entry string) (string, string) {
+	domain := strings.TrimPrefix(entry, "!")
+
+	switch {
+	case strings.HasPrefix(domain, "="):
+		domain = domain[1:]
+	case strings.HasPrefix(domain, "*."):
+		domain = domain[2:]
+	}
+
+	return entry[:len(entry)-len(domain)], domain
+}
+
+// parseEntry returns the normalized domain of a list entry and its scope. A plain domain covers itself and its
+// subdomains, "=domain" only the domain and "*.domain" only its subdomains. A leading "!" turns the entry into an
+// exemption from the entries of parent domains, e.g. "!good.example.com" under "example.com".
+func parseEntry(entry string) (string, entryScope) {
+	marker, domain := splitEntry(strings.TrimSpace(entry))
+	scope := scopeTree
+
+	switch strings.TrimPrefix(marker, "!") {
+	case "=":
+		scope = scopeDomain
+	case "*.":
+		scope = scopeSubdomains
+	}
+
+	if strings.HasPrefix(marker, "!") {
+		scope <<= exemptShift
+	}
+
+	return normalize(domain), scope
+}
+
+// add parses the entry and adds it to the set. Empty entries and public suffixes are skipped, since they would
+// match every domain registered under them.
+func (s domainSet) add(entry string) {
+	domain, scope := parseEntry(entry)
+	if domain == "" || publicSuffixes.isPublicSuffix(domain) {
+		return
+	}
+
+	s[domain] |= scope
+}
+
+// match returns the entry domain that covers the normalized domain and the number of labels stripped to reach it.
+// The most specific entry decides: an exemption ends the walk, so it beats every entry of a parent domain, and it
+// beats a covering entry for the same domain.
+func (s domainSet) match(domain string) (string, int, bool) {
+	if len(s) == 0 {
+		return "", 0, false
+	}
+
+	var found setMatch
+
+	depth := 0
+
+	for suffix := range suffixes(domain) {
+		if found.check(s, suffix, depth); found.done {
+			break
+		}
+
+		depth++
+	}
+
+	return found.entry, found.depth, found.ok
+}
+
+// setMatch is the state of one set during a walk over a domain and its parents, from the most specific name.
+type setMatch struct {
+	// entry and depth are the covering entry and the number of labels stripped to reach it, if ok.
+	entry string
+	depth int
+	ok    bool
+	// done reports that the set has decided: an entry covers the domain or an exemption ends the walk.
+	done bool
+}
+
+// check looks up the name at the given depth of the walk in the set, unless the set has already decided.
+func (m *setMatch) check(s domainSet, name string, depth int) {
+	if m.done {
+		return
+	}
+
+	scope, ok := s[name]
+	if !ok {
+		return
+	}
+
+	covers := scopeDomain
+	if depth > 0 {
+		covers = scopeSubdomains
+	}
+
+	switch {
+	case scope&(covers<<exemptShift) != 0:
+		m.done = true
+	case scope&covers != 0:
+		m.entry, m.depth, m.ok, m.done = name, depth, true, true
+	}
+}
+
+// listed reports whether an entry covers the domain itself, without looking at its parents.
+func (s domainSet) listed(domain string) bool {
+	return s[domain]&scopeDomain != 0
+}
@@ -36,14 +36,18 @@ func main() {
 		"user@gmail.com",
 		"contact@mycompany.com",
 		"test@temp-mail.com",
+		"Jane Doe <jane@mycompany.com>",
+		"user@@mycompany.com",
 	}
 
 	for _, email := range emails {
-		parts := strings.Split(email, "@")
-		if len(parts) == 2 {
-			domain := parts[1]
-			fmt.Printf("Email: %s → Domain: %s (Business: %t)\n",
-				email, domain, validator.IsBusinessDomain(domain))
+		address, err := validator.ParseAddress(email)
+		if err != nil {
+			fmt.Printf("Email: %s → %v\n", email, err)
+			continue
 		}
+
+		fmt.Printf("Email: %s → Domain: %s (Business: %t)\n",
+			email, address.Domain, validator.IsBusinessDomain(address.Domain))
 	}
 }
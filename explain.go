@@ -0,0 +1,252 @@
+package workemailvalidator
+
+import "strings"
+
+// List identifies the domain list an entry was matched in.
+type List int
+
+const (
+	// ListNone means no list entry matched.
+	ListNone List = iota
+	// ListDisposable is the disposable domain list.
+	ListDisposable
+	// ListFree is the free email provider list.
+	ListFree
+	// ListAllowlist is the custom allowlist; a match forces CategoryBusiness.
+	ListAllowlist
+	// ListBlocklist is the custom blocklist; a match forces CategoryDisposable.
+	ListBlocklist
+	// ListDisposableMX is the disposable mail infrastructure list, matched against the domain's MX hosts.
+	ListDisposableMX
+	// ListRelay is the email relay/privacy-alias service list; a match means CategoryRelay.
+	ListRelay
+	// ListFreeTypo means the domain is a likely typo of a free provider; Match is the suggested domain.
+	ListFreeTypo
+	// ListConfusable means the domain spoofs a free or disposable domain with look-alike Unicode characters;
+	// Match is the imitated domain and Category is its category.
+	ListConfusable
+	// ListPattern is the disposable domain patterns; a match means CategoryDisposable and Match is the pattern.
+	ListPattern
+)
+
+// String returns the lowercase name of the list.
+func (l List) String() string {
+	switch l {
+	case ListNone:
+		return "none"
+	case ListDisposable:
+		return "disposable"
+	case ListFree:
+		return "free"
+	case ListAllowlist:
+		return "allowlist"
+	case ListBlocklist:
+		return "blocklist"
+	case ListDisposableMX:
+		return "disposable_mx"
+	case ListRelay:
+		return "relay"
+	case ListFreeTypo:
+		return "free_typo"
+	case ListConfusable:
+		return "confusable"
+	case ListPattern:
+		return "pattern"
+	default:
+		return "unknown"
+	}
+}
+
+// Result explains how a domain was classified.
+type Result struct {
+	// Domain is the normalized (trimmed, lowercased, Punycode) form of the input domain.
+	Domain string
+	// Category is the final classification, the same value Classify returns.
+	Category Category
+	// List is the list that decided the category, or ListNone.
+	List List
+	// Match is the list entry that matched: Domain itself or one of its parents, or the pattern for ListPattern.
+	Match string
+	// Depth is the number of leading labels stripped from Domain to reach Match; 0 is an exact match.
+	Depth int
+	// MX is the outcome of the MX check, or MXNotChecked.
+	MX MXStatus
+	// Provider is the mailbox provider of a business domain, or ProviderUnknown if not checked or identified.
+	Provider Provider
+	// Role reports that the email address is a role account such as "info@". It is false for domains.
+	Role bool
+	// Sector is the kind of organization behind a business domain, or SectorUnknown for other categories.
+	Sector Sector
+	// TLDRisk is the risk tier of the domain's top-level domain, whatever the category.
+	TLDRisk TLDRisk
+}
+
+// invalidResult returns the Result for an invalid domain or address, the starting point of every classification.
+func invalidResult(domain string) Result {
+	return Result{
+		Domain:   domain,
+		Category: CategoryInvalid,
+		List:     ListNone,
+		Match:    "",
+		Depth:    0,
+		MX:       MXNotChecked,
+		Provider: ProviderUnknown,
+		Role:     false,
+		Sector:   SectorUnknown,
+		TLDRisk:  TLDRiskStandard,
+	}
+}
+
+// listMatches holds the closest entry of each list that covers a domain, as found by matchLists.
+type listMatches struct {
+	allowlist  setMatch
+	blocklist  setMatch
+	relay      setMatch
+	disposable setMatch
+	free       setMatch
+}
+
+// matchLists walks the normalized domain and its parents once, checking every list at each level, and stops as
+// soon as every list has decided.
+func (v *Validator) matchLists(domain string) listMatches {
+	var matches listMatches
+
+	checks := [...]struct {
+		set   domainSet
+		match *setMatch
+	}{
+		{v.allowlist, &matches.allowlist},
+		{v.blocklist, &matches.blocklist},
+		{v.relayDomains, &matches.relay},
+		{v.disposableDomains, &matches.disposable},
+		{v.freeDomains, &matches.free},
+	}
+
+	for _, check := range checks {
+		check.match.done = len(check.set) == 0
+	}
+
+	depth := 0
+
+	for suffix := range suffixes(domain) {
+		done := true
+
+		for _, check := range checks {
+			check.match.check(check.set, suffix, depth)
+			done = done && check.match.done
+		}
+
+		if done {
+			break
+		}
+
+		depth++
+	}
+
+	return matches
+}
+
+// classify applies the precedence rules to a normalized, syntactically valid domain and returns its category, the
+// list that decided it, the matching entry or pattern and its depth. The lists are checked in a single walk over
+// the domain and its parents. Precedence: the allowlist/blocklist overrides, then relay services, then disposable,
+// then free, then spoofs of free or disposable domains, then disposable name patterns, then typos of free
+// providers if enabled, otherwise business. Relays come before disposable because disposable feeds often list them.
+func (v *Validator) classify(domain string) (Category, List, string, int) {
+	matches := v.matchLists(domain)
+
+	switch list, match, depth := matches.override(); list {
+	case ListBlocklist:
+		return CategoryDisposable, list, match, depth
+	case ListAllowlist:
+		return CategoryBusiness, list, match, depth
+	}
+
+	switch {
+	case matches.relay.ok:
+		return CategoryRelay, ListRelay, matches.relay.entry, matches.relay.depth
+	case matches.disposable.ok:
+		return CategoryDisposable, ListDisposable, matches.disposable.entry, matches.disposable.depth
+	case matches.free.ok:
+		return CategoryFree, ListFree, matches.free.entry, matches.free.depth
+	}
+
+	if match, category, depth, ok := v.confusable(domain); ok {
+		return category, ListConfusable, match, depth
+	}
+
+	if pattern, depth, ok := v.matchPattern(domain); ok {
+		return CategoryDisposable, ListPattern, pattern, depth
+	}
+
+	if suggestion, ok := v.freeTypo(domain); ok {
+		return CategoryFree, ListFreeTypo, suggestion, 0
+	}
+
+	return CategoryBusiness, ListNone, "", 0
+}
+
+// category returns the category of the domain under the rules of classify, without the details of Explain.
+func (v *Validator) category(domain string) Category {
+	normalized, err := v.normalize(domain)
+	if err != nil || !isValidDomainSyntax(normalized) {
+		return CategoryInvalid
+	}
+
+	category, _, _, _ := v.classify(normalized)
+
+	return category
+}
+
+// explain classifies the normalized domain with classify and adds the details of Result: the top-level domain
+// risk tier, and the sector of business domains. Invalid syntax yields CategoryInvalid.
+func (v *Validator) explain(domain string) Result {
+	result := invalidResult(domain)
+
+	if !isValidDomainSyntax(domain) {
+		return result
+	}
+
+	result.Category, result.List, result.Match, result.Depth = v.classify(domain)
+	result.TLDRisk = v.tldRisk(domain)
+
+	if result.Category == CategoryBusiness {
+		result.Sector = v.sector(domain)
+	}
+
+	return result
+}
+
+// Explain classifies the given domain and reports which list entry decided the category.
+func (v *Validator) Explain(domain string) Result {
+	normalized, err := v.normalize(domain)
+	if err != nil {
+		return invalidResult(strings.ToLower(strings.TrimSpace(domain)))
+	}
+
+	return v.explain(normalized)
+}
+
+// ExplainEmail classifies the domain of the given email address and reports which list entry decided the category.
+// An address without a local part or domain yields a Result with CategoryInvalid and an empty Domain.
+// Role reports whether the address is a role account.
+func (v *Validator) ExplainEmail(email string) Result {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return invalidResult("")
+	}
+
+	result := v.Explain(address.Domain)
+	result.Role = v.isRoleLocalPart(address.Local)
+
+	return result
+}
+
+// Explain classifies the given domain and reports which list entry decided the category.
+func Explain(domain string) Result {
+	return defaultValidator.Explain(domain)
+}
+
+// ExplainEmail classifies the domain of the given email address and reports which list entry decided the category.
+func ExplainEmail(email string) Result {
+	return defaultValidator.ExplainEmail(email)
+}
@@ -0,0 +1,258 @@
+package workemailvalidator
+
+import (
+	"iter"
+	"math"
+	"strings"
+	"sync"
+)
+
+const (
+	// modelSymbols is the alphabet of the character model: the letters a-z plus the word boundary markers.
+	modelSymbols = 28
+	// wordStart and wordEnd are the model symbols that mark the start and the end of a word.
+	wordStart, wordEnd = 26, 27
+
+	// naturalBitsPerChar is the cross-entropy up to which a word reads as natural, e.g. "mkrzysztof";
+	// randomBitsPerChar is the cross-entropy from which it is fully random, e.g. "qzvkhtrw". Most names and
+	// brands score 3-5 bits per character.
+	naturalBitsPerChar = 6.4
+	randomBitsPerChar  = 7.6
+	// maxUnjudgedLetters is the number of letters too few to judge, as in "jd"; the model's judgement gains
+	// confidence with every letter up to fullConfidenceLetters.
+	maxUnjudgedLetters    = 2
+	fullConfidenceLetters = 6
+	// maxDigitSwitches is the number of extra switches between letters and digits that counts as fully random.
+	// A single trailing number, as in "john1990", is natural.
+	maxDigitSwitches = 3
+	// minTrainingWordLength is the length of the shortest word used to train the model.
+	minTrainingWordLength = 3
+
+	// Interpolation weights of the trigram, bigram and unigram estimates, and the unigram smoothing count.
+	trigramWeight, bigramWeight, unigramWeight = 0.6, 0.3, 0.1
+	unigramSmoothing                           = 1
+)
+
+// charModel is a character trigram model of natural words, with linear interpolation down to bigrams and
+// add-one smoothed unigrams so that unseen sequences keep a small probability.
+type charModel struct {
+	trigrams [modelSymbols][modelSymbols][modelSymbols]float64
+	bigrams  [modelSymbols][modelSymbols]float64
+	unigrams [modelSymbols]float64
+	total    float64
+}
+
+// Randomness reports how machine-generated the parts of an email address look.
+type Randomness struct {
+	// LocalPart is the confidence, from 0 to 1, that the local part was machine-generated, e.g. "xk29fjq7".
+	LocalPart float64
+	// Domain is the confidence that the registrable label of the domain, e.g. "acme" in "mail.acme.co.uk",
+	// was machine-generated. Internationalized labels are not judged and score 0.
+	Domain float64
+}
+
+// naturalWords returns the character model of natural words, trained on first use from the embedded corpus:
+// the registrable labels of the free providers, the labels of the Public Suffix List (brand top-level domains,
+// organization and place names) and the role account names. There is no embedded list of business domains to
+// train on, so the Public Suffix List labels stand in for company names; the model has seen few surnames and
+// coined brand names, which is why naturalBitsPerChar leaves a wide margin above their 3-5 bits per character.
+var naturalWords = sync.OnceValue(func() *charModel {
+	var model charModel
+
+	for domain := range freeDomains {
+		if registrable, ok := publicSuffixes.registrableDomain(domain); ok {
+			model.train(registrable[:strings.IndexByte(registrable, '.')])
+		}
+	}
+
+	suffixRules := []map[string]struct{}{publicSuffixes.rules, publicSuffixes.wildcards, publicSuffixes.exceptions}
+
+	for _, rules := range suffixRules {
+		for rule := range rules {
+			for label := range strings.SplitSeq(rule, ".") {
+				if !strings.HasPrefix(label, "xn--") {
+					model.train(label)
+				}
+			}
+		}
+	}
+
+	for role := range roleAccounts {
+		model.train(role)
+	}
+
+	return &model
+})
+
+// train adds the letter runs of the word to the model. Short words are skipped.
+func (m *charModel) train(word string) {
+	for run := range letterRuns(word) {
+		if len(run) < minTrainingWordLength {
+			continue
+		}
+
+		previous, current := wordStart, wordStart
+
+		for i := 0; i <= len(run); i++ {
+			next := wordEnd
+			if i < len(run) {
+				next = int(run[i] - 'a')
+			}
+
+			m.trigrams[previous][current][next]++
+			m.bigrams[current][next]++
+			m.unigrams[next]++
+			m.total++
+
+			previous, current = current, next
+		}
+	}
+}
+
+// bits returns the total cross-entropy of the letter run under the model, in bits, and the number of symbols
+// scored (the letters plus the end of the word).
+func (m *charModel) bits(run string) (float64, int) {
+	total := 0.0
+	previous, current := wordStart, wordStart
+
+	for i := 0; i <= len(run); i++ {
+		next := wordEnd
+		if i < len(run) {
+			next = int(run[i] - 'a')
+		}
+
+		probability := unigramWeight * (m.unigrams[next] + unigramSmoothing) / (m.total + modelSymbols*unigramSmoothing)
+
+		if contextCount := m.bigrams[previous][current]; contextCount > 0 {
+			probability += trigramWeight * m.trigrams[previous][current][next] / contextCount
+		}
+
+		if contextCount := m.unigrams[current]; contextCount > 0 {
+			probability += bigramWeight * m.bigrams[current][next] / contextCount
+		}
+
+		total -= math.Log2(probability)
+		previous, current = current, next
+	}
+
+	return total, len(run) + 1
+}
+
+// letterRuns yields the runs of ASCII letters a-z in the lowercase text, e.g. "jane", "doe" for "jane.doe42".
+func letterRuns(text string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		start := -1
+
+		for i := 0; i <= len(text); i++ {
+			letter := i < len(text) && 'a' <= text[i] && text[i] <= 'z'
+
+			switch {
+			case letter && start < 0:
+				start = i
+			case !letter && start >= 0:
+				if !yield(text[start:i]) {
+					return
+				}
+
+				start = -1
+			}
+		}
+	}
+}
+
+// randomness returns the confidence, from 0 to 1, that the lowercase text was machine-generated. Letter runs
+// are scored by their cross-entropy under the model of natural words, discounted for short texts; frequent
+// switches between letters and digits, as in "a8f3e9c1", score on their own. Other characters separate words.
+func randomness(text string) float64 {
+	model := naturalWords()
+	bits, symbols, letters := 0.0, 0, 0
+
+	for run := range letterRuns(text) {
+		runBits, runSymbols := model.bits(run)
+		bits, symbols, letters = bits+runBits, symbols+runSymbols, letters+len(run)
+	}
+
+	modelScore := 0.0
+	if symbols > 0 {
+		perChar := bits / float64(symbols)
+		modelScore = clamp01((perChar - naturalBitsPerChar) / (randomBitsPerChar - naturalBitsPerChar))
+		modelScore *= clamp01(float64(letters-maxUnjudgedLetters) / (fullConfidenceLetters - maxUnjudgedLetters))
+	}
+
+	return max(modelScore, clamp01(float64(digitSwitches(text)-1)/maxDigitSwitches))
+}
+
+// digitSwitches counts the switches between letters and digits within the words of the text.
+func digitSwitches(text string) int {
+	switches := 0
+
+	for i := 1; i < len(text); i++ {
+		previousDigit, digit := isDigit(text[i-1]), isDigit(text[i])
+		previousLetter, letter := 'a' <= text[i-1] && text[i-1] <= 'z', 'a' <= text[i] && text[i] <= 'z'
+
+		if previousDigit && letter || previousLetter && digit {
+			switches++
+		}
+	}
+
+	return switches
+}
+
+// isDigit reports whether the byte is an ASCII digit.
+func isDigit(char byte) bool {
+	return '0' <= char && char <= '9'
+}
+
+// clamp01 limits the value to the range 0 to 1.
+func clamp01(value float64) float64 {
+	return min(max(value, 0), 1)
+}
+
+// localPartRandomness returns the confidence that the local part was machine-generated. Case, quotes and
+// subaddress tags are ignored.
+func localPartRandomness(local string) float64 {
+	local, _, _ = strings.Cut(strings.ToLower(strings.Trim(local, `"`)), "+")
+
+	return randomness(local)
+}
+
+// domainRandomness returns the confidence that the registrable label of a normalized domain was machine-generated.
+func domainRandomness(domain string) float64 {
+	registrable, ok := publicSuffixes.registrableDomain(domain)
+	if !ok {
+		return 0
+	}
+
+	label := registrable[:strings.IndexByte(registrable, '.')]
+	if strings.HasPrefix(label, "xn--") {
+		return 0
+	}
+
+	return randomness(label)
+}
+
+// DetectRandomness reports how machine-generated the local part and the domain of the email address look, as
+// bots register addresses such as "xk29fjq7@qzvkhtrw.xyz" on domains no feed lists yet. Each part is scored with
+// a character trigram model trained on the names of free providers, the labels of the Public Suffix List and role
+// account names, in place of a corpus of business domains, which is not embedded: the less likely
+// its letters are under the model, and the more often it switches between letters and digits, the higher the
+// confidence. Addresses that do not parse score 0.
+func (v *Validator) DetectRandomness(email string) Randomness {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return Randomness{LocalPart: 0, Domain: 0}
+	}
+
+	randomness := Randomness{LocalPart: localPartRandomness(address.Local), Domain: 0}
+
+	if domain, err := v.normalize(address.Domain); err == nil && isValidDomainSyntax(domain) {
+		randomness.Domain = domainRandomness(domain)
+	}
+
+	return randomness
+}
+
+// DetectRandomness reports how machine-generated the local part and the domain of the email address look.
+func DetectRandomness(email string) Randomness {
+	return defaultValidator.DetectRandomness(email)
+}
@@ -0,0 +1,189 @@
+package workemailvalidator
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// ErrMalformedEntry is reported, wrapped in a *ParseError, for list lines that are not valid domains.
+var ErrMalformedEntry = errors.New("malformed domain entry")
+
+// ErrNoListFiles is returned when a pattern or directory matches no list files.
+var ErrNoListFiles = errors.New("no domain list files found")
+
+// ParseError reports a malformed line in a domain list.
+type ParseError struct {
+	// Name is the file the line was read from, or empty when reading from an io.Reader.
+	Name string
+	// Line is the 1-based line number.
+	Line int
+	// Text is the offending line with surrounding whitespace removed.
+	Text string
+	// Err describes the problem and wraps ErrMalformedEntry.
+	Err error
+}
+
+// Error returns the error in "name:line: text: reason" form.
+func (e *ParseError) Error() string {
+	if e.Name == "" {
+		return fmt.Sprintf("line %d: %q: %v", e.Line, e.Text, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d: %q: %v", e.Name, e.Line, e.Text, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseDomainLine parses a single list line into an entry: the normalized domain behind its "!", "=" or "*."
+// marker, if any. It reports false for blank and comment lines.
+func parseDomainLine(line string) (string, bool, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", false, nil
+	}
+
+	if strings.ContainsFunc(line, isSpace) {
+		return "", false, fmt.Errorf("%w: contains whitespace", ErrMalformedEntry)
+	}
+
+	marker, domain := splitEntry(line)
+	if domain != "" && strings.IndexByte("!=*", domain[0]) >= 0 {
+		return "", false, fmt.Errorf("%w: misplaced entry marker", ErrMalformedEntry)
+	}
+
+	domain = normalize(domain)
+	if !isValidDomainSyntax(domain) {
+		return "", false, fmt.Errorf("%w: invalid domain syntax", ErrMalformedEntry)
+	}
+
+	return marker + domain, true, nil
+}
+
+// isSpace reports whether the rune is an ASCII space or control character.
+func isSpace(r rune) bool {
+	return r <= ' ' || r == 127
+}
+
+// readDomains parses a domain list, collecting a *ParseError for every malformed line.
+func readDomains(reader io.Reader, name string) ([]string, error) {
+	var (
+		domains []string
+		errs    []error
+	)
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+
+		domain, ok, err := parseDomainLine(scanner.Text())
+		if err != nil {
+			errs = append(errs, &ParseError{Name: name, Line: lineNumber, Text: strings.TrimSpace(scanner.Text()), Err: err})
+			continue
+		}
+
+		if ok {
+			domains = append(domains, domain)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading domain list %s: %w", name, err)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return domains, nil
+}
+
+// ReadDomains reads a domain list in the embedded format: one entry per line, blank lines and lines starting
+// with '#' are ignored. An entry is a domain, matching itself and its subdomains; "=example.com", matching only
+// the domain; or "*.example.com", matching only its subdomains. A leading "!", as in "!good.example.com",
+// exempts the names an entry would match from the entries of parent domains. Domains are normalized like
+// lookups and keep their marker. Every malformed line is reported as a *ParseError, joined into the returned error.
+//
+// The result can be passed to options such as WithDisposableDomains:
+//
+//	domains, err := workemailvalidator.ReadDomainsFile("/etc/app/disposable.txt")
+//	if err != nil { ... }
+//	v := workemailvalidator.New(workemailvalidator.WithDisposableDomains(domains...))
+func ReadDomains(reader io.Reader) ([]string, error) {
+	return readDomains(reader, "")
+}
+
+// ReadDomainsFile reads a domain list file. See ReadDomains for the format.
+func ReadDomainsFile(name string) ([]string, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("opening domain list: %w", err)
+	}
+	defer file.Close()
+
+	return readDomains(file, name)
+}
+
+// ReadDomainsFS reads and concatenates every file in fsys matching the fs.Glob pattern, in lexical order.
+// It returns ErrNoListFiles if the pattern matches nothing. See ReadDomains for the format.
+func ReadDomainsFS(fsys fs.FS, pattern string) ([]string, error) {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("matching domain lists: %w", err)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoListFiles, pattern)
+	}
+
+	var (
+		domains []string
+		errs    []error
+	)
+
+	for _, name := range names {
+		fileDomains, err := readDomainsFS(fsys, name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		domains = append(domains, fileDomains...)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return domains, nil
+}
+
+// readDomainsFS reads a single list file from fsys.
+func readDomainsFS(fsys fs.FS, name string) ([]string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("opening domain list: %w", err)
+	}
+	defer file.Close()
+
+	return readDomains(file, name)
+}
+
+// ReadDomainsDir reads every "*.txt" file in the directory. See ReadDomainsFS.
+func ReadDomainsDir(dir string) ([]string, error) {
+	domains, err := ReadDomainsFS(os.DirFS(dir), "*.txt")
+	if err != nil {
+		return nil, fmt.Errorf("reading domain lists from %s: %w", dir, err)
+	}
+
+	return domains, nil
+}
@@ -0,0 +1,203 @@
+package workemailvalidator
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// ErrNoMailServer means the domain does not accept mail: it has a null MX record or no MX, A or AAAA records.
+var ErrNoMailServer = errors.New("domain does not accept mail")
+
+// MXStatus is the outcome of an MX lookup.
+type MXStatus int
+
+const (
+	// MXNotChecked means no MX lookup was performed.
+	MXNotChecked MXStatus = iota
+	// MXFound means the domain publishes MX records.
+	MXFound
+	// MXImplicit means the domain has no MX records but has A or AAAA records,
+	// which act as an implicit MX (RFC 5321 section 5.1).
+	MXImplicit
+	// MXNull means the domain publishes a null MX record and accepts no mail (RFC 7505).
+	MXNull
+	// MXNone means the domain has no MX, A or AAAA records.
+	MXNone
+	// MXError means the lookup failed, e.g. on a timeout, so the status is unknown.
+	MXError
+)
+
+// String returns the lowercase name of the status.
+func (s MXStatus) String() string {
+	switch s {
+	case MXNotChecked:
+		return "not_checked"
+	case MXFound:
+		return "found"
+	case MXImplicit:
+		return "implicit"
+	case MXNull:
+		return "null"
+	case MXNone:
+		return "none"
+	case MXError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// AcceptsMail reports whether the status means the domain has a mail server.
+func (s MXStatus) AcceptsMail() bool {
+	return s == MXFound || s == MXImplicit
+}
+
+// MXResult is the outcome of LookupMX.
+type MXResult struct {
+	// Status is the outcome of the lookup.
+	Status MXStatus
+	// Hosts are the mail servers ordered by preference, without trailing dots.
+	// For an implicit MX it is the domain itself.
+	Hosts []string
+}
+
+// resolver returns the configured resolver or the system resolver.
+func (v *Validator) resolver() Resolver {
+	if v.dnsResolver != nil {
+		return v.dnsResolver
+	}
+
+	return net.DefaultResolver
+}
+
+// LookupMX looks up the mail servers of the domain, honoring RFC 7505 null MX records and falling back to
+// A/AAAA records when no MX records exist. Lookup failures are returned with Status MXError.
+func (v *Validator) LookupMX(ctx context.Context, domain string) (MXResult, error) {
+	normalized, err := v.NormalizeDomain(domain)
+	if err != nil {
+		return MXResult{Status: MXError, Hosts: nil}, err
+	}
+
+	return lookupMX(ctx, v.resolver(), normalized)
+}
+
+// lookupMX resolves the MX records of an already normalized domain.
+func lookupMX(ctx context.Context, resolver Resolver, domain string) (MXResult, error) {
+	records, err := resolver.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return MXResult{Status: MXError, Hosts: nil}, fmt.Errorf("looking up MX records of %s: %w", domain, err)
+	}
+
+	if len(records) > 0 {
+		return mxResultFromRecords(records), nil
+	}
+
+	addresses, err := resolver.LookupHost(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return MXResult{Status: MXError, Hosts: nil}, fmt.Errorf("looking up addresses of %s: %w", domain, err)
+	}
+
+	if len(addresses) == 0 {
+		return MXResult{Status: MXNone, Hosts: nil}, nil
+	}
+
+	return MXResult{Status: MXImplicit, Hosts: []string{domain}}, nil
+}
+
+// mxResultFromRecords orders the records by preference and detects null MX records.
+func mxResultFromRecords(records []*net.MX) MXResult {
+	sorted := slices.Clone(records)
+	slices.SortStableFunc(sorted, func(a, b *net.MX) int {
+		return cmp.Compare(a.Pref, b.Pref)
+	})
+
+	hosts := make([]string, 0, len(sorted))
+
+	for _, record := range sorted {
+		if host := strings.TrimSuffix(strings.ToLower(record.Host), "."); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+
+	// A null MX is a single record with an empty (root) host; treat any set without real hosts the same way.
+	if len(hosts) == 0 {
+		return MXResult{Status: MXNull, Hosts: nil}
+	}
+
+	return MXResult{Status: MXFound, Hosts: hosts}
+}
+
+// ExplainContext is Explain followed by DNS checks of business domains, if enabled when building the
+// validator. With WithDisposableMXCheck, domains whose MX hosts belong to disposable mail infrastructure are
+// reported as CategoryDisposable with ListDisposableMX. With WithMXCheck, domains that do not accept mail are
+// reported as CategoryNoMail. With WithProviderCheck, the mailbox provider of the remaining business domains
+// is reported in Provider. Failed lookups keep the domain's category and report MXError.
+func (v *Validator) ExplainContext(ctx context.Context, domain string) Result {
+	result := v.Explain(domain)
+
+	if result.Category != CategoryBusiness || !v.mxCheck && !v.disposableMXCheck && !v.providerCheck {
+		return result
+	}
+
+	mx, err := lookupMX(ctx, v.resolver(), result.Domain)
+	result.MX = mx.Status
+
+	if v.disposableMXCheck && err == nil {
+		if match, ok := v.matchDisposableMX(ctx, mx.Hosts); ok {
+			result.Category, result.List, result.Match, result.Depth = CategoryDisposable, ListDisposableMX, match, 0
+			result.Sector = SectorUnknown
+			return result
+		}
+	}
+
+	if v.mxCheck && (mx.Status == MXNull || mx.Status == MXNone) {
+		result.Category, result.Sector = CategoryNoMail, SectorUnknown
+		return result
+	}
+
+	if v.providerCheck && err == nil {
+		// A failed TXT lookup only leaves the provider unknown; the classification stands.
+		result.Provider, _ = v.fingerprint(ctx, result.Domain, mx.Hosts)
+	}
+
+	return result
+}
+
+// ExplainEmailContext is ExplainContext for the domain of the email address.
+func (v *Validator) ExplainEmailContext(ctx context.Context, email string) Result {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return invalidResult("")
+	}
+
+	result := v.ExplainContext(ctx, address.Domain)
+	result.Role = v.isRoleLocalPart(address.Local)
+
+	return result
+}
+
+// ValidateWorkEmailContext is ValidateWorkEmail followed by the DNS checks of ExplainContext, if enabled.
+// Domains on disposable mail infrastructure fail with ErrDisposableMX, which also matches ErrDisposable, and
+// domains that do not accept mail fail with ErrNoMailServer.
+func (v *Validator) ValidateWorkEmailContext(ctx context.Context, email string) error {
+	if err := v.ValidateWorkEmail(email); err != nil || !v.mxCheck && !v.disposableMXCheck {
+		return err
+	}
+
+	result := v.ExplainEmailContext(ctx, email)
+	if reason := reasonFor(result); reason != nil {
+		return &ValidationError{Input: email, Reason: reason, Result: result, Err: nil}
+	}
+
+	return nil
+}
+
+// LookupMX looks up the mail servers of the domain with the system resolver.
+func LookupMX(ctx context.Context, domain string) (MXResult, error) {
+	return defaultValidator.LookupMX(ctx, domain)
+}
@@ -0,0 +1,211 @@
+package workemailvalidator
+
+import (
+	"maps"
+	"strings"
+)
+
+// Option configures a Validator created by New.
+type Option func(*Validator)
+
+// WithDisposableDomains replaces the embedded disposable domain list with the given domains.
+// Entries are normalized the same way lookups are, so Unicode and mixed-case entries are accepted, and use
+// the list file syntax of ReadDomains, such as "*.example.com" or "!good.example.com".
+// The embedded name patterns of the domain rules are dropped with the list, so only the given domains are
+// disposable; patterns set with WithDomainRules apply whatever the order of the options.
+func WithDisposableDomains(domains ...string) Option {
+	return func(v *Validator) {
+		v.replaceDisposableDomains(newDomainSet(domains))
+	}
+}
+
+// replaceDisposableDomains replaces the disposable list and drops the embedded name patterns with it, unless
+// custom rules were set with WithDomainRules.
+func (v *Validator) replaceDisposableDomains(set domainSet) {
+	v.disposableDomains = set
+
+	if !v.domainRules.custom {
+		v.domainRules.patterns, v.domainRules.regexp = nil, nil
+	}
+}
+
+// WithFreeDomains replaces the embedded free domain list with the given domains.
+// Entries are normalized the same way lookups are, so Unicode and mixed-case entries are accepted, and use
+// the list file syntax of ReadDomains.
+func WithFreeDomains(domains ...string) Option {
+	return func(v *Validator) {
+		v.freeDomains = newDomainSet(domains)
+	}
+}
+
+// WithDisposableSources replaces the embedded provenance data: a map of disposable domain to the ids of the
+// feeds that reported it. It is used by Sources and WithMinSources.
+func WithDisposableSources(sources map[string][]string) Option {
+	return func(v *Validator) {
+		v.disposableSources = make(map[string][]string, len(sources))
+
+		for domain, ids := range sources {
+			v.disposableSources[normalize(domain)] = splitSourceIDs(strings.Join(ids, ","))
+		}
+	}
+}
+
+// WithMinSources ignores disposable entries reported by fewer than n feeds, cutting false positives from
+// single noisy feeds. Entries without recorded provenance are kept, since their feed count is unknown; the
+// embedded provenance is empty until the next list update, so supply it with WithDisposableSources.
+func WithMinSources(n int) Option {
+	return func(v *Validator) {
+		v.minSources = n
+	}
+}
+
+// WithAllowlist marks the given domains and their subdomains as business, overriding the disposable and free lists.
+// Use it to fix false positives such as a partner company on a domain tagged by a disposable feed.
+// Entries use the list file syntax of ReadDomains, so "=example.com" marks only the domain itself.
+// Like all list entries, public suffixes such as "co.uk" are ignored.
+func WithAllowlist(domains ...string) Option {
+	return func(v *Validator) {
+		v.allowlist = newDomainSet(domains)
+	}
+}
+
+// WithBlocklist marks the given domains and their subdomains as disposable, overriding the free list.
+// When both overrides match, the more specific entry wins; an entry in both lists is treated as blocked.
+// Public suffixes such as "co.uk" are ignored, so an entry never blocks a whole registry.
+func WithBlocklist(domains ...string) Option {
+	return func(v *Validator) {
+		v.blocklist = newDomainSet(domains)
+	}
+}
+
+// WithIDNAProfile selects the IDNA rules used to convert internationalized domains before lookup.
+// The default is IDNAPunycode.
+func WithIDNAProfile(profile IDNAProfile) Option {
+	return func(v *Validator) {
+		v.idnaProfile = profile
+	}
+}
+
+// WithStrictIDN treats domains that fail IDNA conversion as invalid instead of looking up the raw domain.
+// Combine it with IDNALookup or IDNARegistration to reject malformed Unicode domains.
+func WithStrictIDN() Option {
+	return func(v *Validator) {
+		v.strictIDN = true
+	}
+}
+
+// WithResolver sets the DNS resolver used for MX lookups. The default is net.DefaultResolver.
+func WithResolver(resolver Resolver) Option {
+	return func(v *Validator) {
+		v.dnsResolver = resolver
+	}
+}
+
+// WithMXCheck makes the context-aware methods (ExplainContext, ValidateWorkEmailContext, ...) look up the MX
+// records of business domains and report domains that do not accept mail.
+func WithMXCheck() Option {
+	return func(v *Validator) {
+		v.mxCheck = true
+	}
+}
+
+// WithDisposableMXCheck makes the context-aware methods resolve the MX hosts of business domains and report
+// domains served by disposable mail infrastructure as disposable, catching fresh domains missing from the lists.
+func WithDisposableMXCheck() Option {
+	return func(v *Validator) {
+		v.disposableMXCheck = true
+	}
+}
+
+// WithDisposableMXHosts replaces the embedded disposable mail infrastructure list. Entries are host names,
+// matching themselves and their subdomains, or IP addresses and CIDR ranges, matching resolved MX addresses.
+func WithDisposableMXHosts(entries ...string) Option {
+	return func(v *Validator) {
+		v.disposableMX = newMXRules(entries)
+	}
+}
+
+// WithDomainRules replaces the embedded disposable domain rules. Each rule is "tld <tld> <tier>", setting the
+// risk tier of a top-level domain to standard, elevated or high, "glob <pattern>" or "regex <pattern>", flagging
+// unlisted domains whose registrable domain matches as disposable. Malformed rules are ignored.
+func WithDomainRules(rules ...string) Option {
+	return func(v *Validator) {
+		v.domainRules = newDomainRules(rules)
+		v.domainRules.custom = true
+	}
+}
+
+// WithProviderCheck makes the context-aware methods identify the mailbox provider of business domains from
+// their MX and SPF records and report it in Result.Provider.
+func WithProviderCheck() Option {
+	return func(v *Validator) {
+		v.providerCheck = true
+	}
+}
+
+// WithTypoDetection classifies likely typos of free providers, such as "gmial.com", as CategoryFree with
+// ListFreeTypo instead of business. Result.Match is the suggested domain.
+func WithTypoDetection() Option {
+	return func(v *Validator) {
+		v.typoCheck = true
+	}
+}
+
+// WithRoleAccounts replaces the embedded role account list with the given local parts, e.g. "info" or "no-reply".
+func WithRoleAccounts(localParts ...string) Option {
+	return func(v *Validator) {
+		v.roleAccounts = newRoleSet(localParts)
+	}
+}
+
+// WithExtraRoleAccounts adds local parts to the role account list.
+func WithExtraRoleAccounts(localParts ...string) Option {
+	return func(v *Validator) {
+		extended := newRoleSet(localParts)
+		maps.Copy(extended, v.roleAccounts)
+		v.roleAccounts = extended
+	}
+}
+
+// WithRoleAccountRejection makes IsWorkEmail and ValidateWorkEmail reject role accounts such as "info@",
+// "sales@" or "noreply@" even on business domains.
+func WithRoleAccountRejection() Option {
+	return func(v *Validator) {
+		v.rejectRoles = true
+	}
+}
+
+// WithCanonicalRules adds provider rules to Canonicalize, e.g. for a company domain that ignores dots.
+// A rule replaces any built-in rule for the same domain.
+func WithCanonicalRules(rules ...CanonicalRule) Option {
+	return func(v *Validator) {
+		extended := maps.Clone(v.canonicalRules)
+		addCanonicalRules(extended, rules)
+		v.canonicalRules = extended
+	}
+}
+
+// WithRelayDomains replaces the embedded email relay/privacy-alias service list with the given domains.
+func WithRelayDomains(domains ...string) Option {
+	return func(v *Validator) {
+		v.relayDomains = newDomainSet(domains)
+	}
+}
+
+// WithUniversityDomains replaces the embedded university list, which complements the academic suffix rules with
+// universities outside them.
+func WithUniversityDomains(domains ...string) Option {
+	return func(v *Validator) {
+		v.universityDomains = newDomainSet(domains)
+	}
+}
+
+// WithRiskWeights changes the points the given signals add to ScoreRisk at full strength. Signals missing from
+// the map keep their default weight; a weight of 0 disables a signal.
+func WithRiskWeights(weights map[Signal]float64) Option {
+	return func(v *Validator) {
+		extended := maps.Clone(v.riskWeights)
+		maps.Copy(extended, weights)
+		v.riskWeights = extended
+	}
+}
@@ -0,0 +1,14 @@
+package workemailvalidator
+
+// override returns the most specific allowlist or blocklist entry matching the domain or one of its parents.
+// A domain present in both lists at the same level is treated as blocked.
+func (m listMatches) override() (List, string, int) {
+	switch {
+	case m.blocklist.ok && (!m.allowlist.ok || m.blocklist.depth <= m.allowlist.depth):
+		return ListBlocklist, m.blocklist.entry, m.blocklist.depth
+	case m.allowlist.ok:
+		return ListAllowlist, m.allowlist.entry, m.allowlist.depth
+	default:
+		return ListNone, "", 0
+	}
+}
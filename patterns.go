@@ -0,0 +1,225 @@
+package workemailvalidator
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TLDRisk is the abuse risk tier of a top-level domain.
+type TLDRisk int
+
+const (
+	// TLDRiskStandard means the top-level domain has no known abuse problem. It is the tier of unlisted TLDs.
+	TLDRiskStandard TLDRisk = iota
+	// TLDRiskElevated means the top-level domain is cheap and popular with disposable services, e.g. ".xyz".
+	TLDRiskElevated
+	// TLDRiskHigh means most domains registered under the top-level domain are throwaway or abusive, e.g. ".tk".
+	TLDRiskHigh
+)
+
+// String returns the lowercase name of the tier.
+func (r TLDRisk) String() string {
+	switch r {
+	case TLDRiskStandard:
+		return "standard"
+	case TLDRiskElevated:
+		return "elevated"
+	case TLDRiskHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// parseTLDRisk parses the name of a tier, as returned by TLDRisk.String.
+func parseTLDRisk(name string) (TLDRisk, bool) {
+	for _, risk := range []TLDRisk{TLDRiskStandard, TLDRiskElevated, TLDRiskHigh} {
+		if risk.String() == name {
+			return risk, true
+		}
+	}
+
+	return TLDRiskStandard, false
+}
+
+// domainPattern is a glob or an anchored regular expression matched against domain names.
+type domainPattern struct {
+	// source is the pattern as written in the rule, reported in Result.Match.
+	source string
+	// group is the capture group of the pattern in the combined expression of its rules.
+	group int
+}
+
+// domainRules holds the top-level domain risk tiers and the name patterns of disposable domains.
+type domainRules struct {
+	tlds     map[string]TLDRisk
+	patterns []domainPattern
+	// regexp is the alternation of all patterns, globs translated to regular expressions, each in its own capture
+	// group, so a name is matched against all of them at once. It is nil if there are none.
+	regexp *regexp.Regexp
+	// custom reports that the rules were set with WithDomainRules, so WithDisposableDomains keeps their patterns.
+	custom bool
+}
+
+// loadDomainRules parses "tld <tld> <tier>", "glob <pattern>" and "regex <pattern>" rules, one per line.
+func loadDomainRules(data string) domainRules {
+	var entries []string
+
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entries = append(entries, line)
+	}
+
+	return newDomainRules(entries)
+}
+
+// newDomainRules sorts the rules into top-level domain tiers and patterns. Malformed rules, unknown tiers and
+// patterns that do not compile are skipped. Patterns keep their order, which decides the reported match.
+func newDomainRules(entries []string) domainRules {
+	rules := domainRules{tlds: make(map[string]TLDRisk), patterns: nil, regexp: nil, custom: false}
+	alternatives := make([]string, 0, len(entries))
+	group := 1
+
+	for _, entry := range entries {
+		kind, value, _ := strings.Cut(strings.TrimSpace(entry), " ")
+		value = strings.TrimSpace(value)
+
+		switch kind {
+		case "tld":
+			fields := strings.Fields(value)
+			if len(fields) != 2 { //nolint:mnd // top-level domain and tier
+				continue
+			}
+
+			tld := normalize(strings.TrimPrefix(fields[0], "."))
+			if risk, ok := parseTLDRisk(strings.ToLower(fields[1])); ok && tld != "" {
+				rules.tlds[tld] = risk
+			}
+		case "glob":
+			if _, err := path.Match(strings.ToLower(value), ""); value != "" && err == nil {
+				rules.patterns = append(rules.patterns, domainPattern{source: value, group: group})
+				alternatives = append(alternatives, "("+globToRegexp(strings.ToLower(value))+")")
+				group++
+			}
+		case "regex":
+			if compiled, err := regexp.Compile(value); value != "" && err == nil {
+				rules.patterns = append(rules.patterns, domainPattern{source: value, group: group})
+				alternatives = append(alternatives, "("+value+")")
+				group += 1 + compiled.NumSubexp()
+			}
+		}
+	}
+
+	if len(alternatives) > 0 {
+		// Each alternative compiles on its own, so their alternation does too.
+		rules.regexp = regexp.MustCompile("^(?:" + strings.Join(alternatives, "|") + ")$")
+	}
+
+	return rules
+}
+
+// match returns the first pattern that the normalized name matches.
+func (r domainRules) match(name string) (string, bool) {
+	if r.regexp == nil {
+		return "", false
+	}
+
+	groups := r.regexp.FindStringSubmatchIndex(name)
+	if groups == nil {
+		return "", false
+	}
+
+	for _, pattern := range r.patterns {
+		if groups[2*pattern.group] >= 0 {
+			return pattern.source, true
+		}
+	}
+
+	return "", false
+}
+
+// globToRegexp translates a valid glob into a regular expression that keeps "*", "?" and character classes
+// within a label: "*" matches any run of characters but dots, "?" one of them, and a class never matches a dot.
+func globToRegexp(glob string) string {
+	var builder strings.Builder
+
+	inClass := false
+
+	for i := 0; i < len(glob); i++ {
+		char := glob[i]
+
+		switch {
+		case char == '\\':
+			// An escaped character is literal; escaping punctuation keeps "-" literal inside a class too.
+			i++
+			builder.WriteString(escapeGlobChar(glob[i]))
+		case inClass && char == ']':
+			builder.WriteByte(']')
+
+			inClass = false
+		case inClass && char == '-':
+			builder.WriteByte('-')
+		case inClass:
+			builder.WriteString(escapeGlobChar(char))
+		case char == '[' && i+1 < len(glob) && glob[i+1] == '^':
+			builder.WriteString(`[^.`)
+
+			inClass = true
+			i++
+		case char == '[':
+			builder.WriteString(`[`)
+
+			inClass = true
+		case char == '*':
+			builder.WriteString(`[^.]*`)
+		case char == '?':
+			builder.WriteString(`[^.]`)
+		default:
+			builder.WriteString(escapeGlobChar(char))
+		}
+	}
+
+	return builder.String()
+}
+
+// escapeGlobChar returns the character as a literal in a regular expression, inside or outside a class.
+func escapeGlobChar(char byte) string {
+	if char < utf8.RuneSelf && !unicode.IsLetter(rune(char)) && !unicode.IsDigit(rune(char)) {
+		return `\` + string(char)
+	}
+
+	return string(char)
+}
+
+// matchPattern returns the first pattern that the registrable domain of the normalized domain matches, and the
+// number of labels stripped to reach it. Only the registrable domain is matched, since names under it, such as
+// "tempmail.acme.com", are chosen by its owner rather than registered by a disposable service.
+func (v *Validator) matchPattern(domain string) (string, int, bool) {
+	if len(v.domainRules.patterns) == 0 {
+		return "", 0, false
+	}
+
+	registrable, ok := publicSuffixes.registrableDomain(domain)
+	if !ok {
+		return "", 0, false
+	}
+
+	pattern, ok := v.domainRules.match(registrable)
+	if !ok {
+		return "", 0, false
+	}
+
+	return pattern, strings.Count(domain[:len(domain)-len(registrable)], "."), true
+}
+
+// tldRisk returns the risk tier of the top-level domain of the normalized domain.
+func (v *Validator) tldRisk(domain string) TLDRisk {
+	return v.domainRules.tlds[domain[strings.LastIndexByte(domain, '.')+1:]]
+}
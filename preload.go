@@ -2,31 +2,117 @@ package workemailvalidator
 
 import (
 	_ "embed"
+	"slices"
 	"strings"
 )
 
 //go:embed data/disposable_domains.txt
 var disposableDomainsData string
 
+//go:embed data/disposable_sources.txt
+var disposableSourcesData string
+
+//go:embed data/disposable_mx.txt
+var disposableMXData string
+
+//go:embed data/domain_rules.txt
+var domainRulesData string
+
 //go:embed data/free_domains.txt
 var freeDomainsData string
 
+//go:embed data/relay_domains.txt
+var relayDomainsData string
+
+//go:embed data/university_domains.txt
+var universityDomainsData string
+
+//go:embed data/role_accounts.txt
+var roleAccountsData string
+
+//go:embed data/public_suffix_list.dat
+var publicSuffixListData string
+
+//go:embed data/confusables.txt
+var confusablesData string
+
 var (
+	publicSuffixes    = loadPublicSuffixList(publicSuffixListData)
 	disposableDomains = loadDomains(disposableDomainsData)
+	disposableSources = loadSources(disposableSourcesData)
+	disposableMX      = loadMXRules(disposableMXData)
+	domainRuleSet     = loadDomainRules(domainRulesData)
 	freeDomains       = loadDomains(freeDomainsData)
+	relayDomains      = loadDomains(relayDomainsData)
+	universityDomains = loadDomains(universityDomainsData)
+	roleAccounts      = loadRoleAccounts(roleAccountsData)
+	canonicalRuleSet  = newCanonicalRuleSet(canonicalRules)
+
+	confusablePrototypes = loadConfusables(confusablesData)
 )
 
-func loadDomains(data string) map[string]struct{} {
-	domains := make(map[string]struct{})
+// loadDomains parses an embedded domain list, one entry per line: a domain, "=domain" for the domain only,
+// "*.domain" for its subdomains only, each optionally prefixed with "!" to exempt it from parent entries.
+// Entries that are public suffixes are dropped, since they would match every domain registered under them.
+func loadDomains(data string) domainSet {
+	domains := make(domainSet)
 
 	for line := range strings.Lines(data) {
-		line = strings.TrimSpace(line)
+		line = strings.ToLower(strings.TrimSpace(line))
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
 
-		domains[strings.ToLower(line)] = struct{}{}
+		domains.add(line)
 	}
 
 	return domains
 }
+
+// loadSources parses "<domain> <source-id>[,<source-id>...]" lines into a map of domain to sorted source ids.
+// Domains are normalized as in WithDisposableSources.
+func loadSources(data string) map[string][]string {
+	sources := make(map[string][]string)
+
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain, ids, _ := strings.Cut(line, " ")
+		sources[normalize(domain)] = splitSourceIDs(ids)
+	}
+
+	return sources
+}
+
+// splitSourceIDs splits a comma-separated list of source ids, dropping empty and duplicate ids.
+func splitSourceIDs(ids string) []string {
+	var result []string
+
+	for id := range strings.SplitSeq(ids, ",") {
+		id = strings.TrimSpace(id)
+		if id == "" || slices.Contains(result, id) {
+			continue
+		}
+
+		result = append(result, id)
+	}
+
+	slices.Sort(result)
+
+	return result
+}
+
+// newDomainSet builds a lookup set from the given entries, in the list format of loadDomains, skipping empty
+// entries and public suffixes.
+func newDomainSet(entries []string) domainSet {
+	set := make(domainSet, len(entries))
+
+	for _, entry := range entries {
+		set.add(entry)
+	}
+
+	return set
+}
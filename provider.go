@@ -0,0 +1,166 @@
+package workemailvalidator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Provider is the mailbox provider hosting a domain's mail.
+type Provider int
+
+const (
+	// ProviderUnknown means the provider was not checked or could not be identified.
+	ProviderUnknown Provider = iota
+	// ProviderGoogleWorkspace is Google Workspace (formerly G Suite).
+	ProviderGoogleWorkspace
+	// ProviderMicrosoft365 is Microsoft 365 (Exchange Online).
+	ProviderMicrosoft365
+	// ProviderZoho is Zoho Mail.
+	ProviderZoho
+	// ProviderProton is Proton Mail.
+	ProviderProton
+	// ProviderSelfHosted means the domain's mail servers are under the domain itself.
+	ProviderSelfHosted
+)
+
+// String returns the lowercase name of the provider.
+func (p Provider) String() string {
+	switch p {
+	case ProviderUnknown:
+		return "unknown"
+	case ProviderGoogleWorkspace:
+		return "google_workspace"
+	case ProviderMicrosoft365:
+		return "microsoft_365"
+	case ProviderZoho:
+		return "zoho"
+	case ProviderProton:
+		return "proton"
+	case ProviderSelfHosted:
+		return "self_hosted"
+	default:
+		return "unknown"
+	}
+}
+
+// providerFingerprint maps the MX hosts and SPF includes of a hosted mailbox provider to the provider.
+// Entries are domain suffixes: an MX host or SPF include matches an entry equal to it or one of its parents.
+type providerFingerprint struct {
+	provider    Provider
+	mxHosts     []string
+	spfIncludes []string
+}
+
+// providerFingerprints lists the known hosted mailbox providers.
+var providerFingerprints = []providerFingerprint{
+	{
+		provider:    ProviderGoogleWorkspace,
+		mxHosts:     []string{"google.com", "googlemail.com", "smtp.google.com"},
+		spfIncludes: []string{"_spf.google.com"},
+	},
+	{
+		provider:    ProviderMicrosoft365,
+		mxHosts:     []string{"mail.protection.outlook.com"},
+		spfIncludes: []string{"spf.protection.outlook.com"},
+	},
+	{
+		provider:    ProviderZoho,
+		mxHosts:     []string{"zoho.com", "zoho.eu", "zoho.in", "zoho.com.au", "zoho.jp", "zohomail.com"},
+		spfIncludes: []string{"zoho.com", "zoho.eu", "zoho.in", "zoho.com.au", "zoho.jp", "zohomail.com"},
+	},
+	{
+		provider:    ProviderProton,
+		mxHosts:     []string{"protonmail.ch", "proton.me"},
+		spfIncludes: []string{"_spf.protonmail.ch"},
+	},
+}
+
+// LookupProvider identifies the mailbox provider hosting the domain's mail. The MX hosts are matched first,
+// then the include mechanisms of the SPF record, so domains behind a mail security gateway are still
+// identified. Domains whose MX hosts are under the domain itself are ProviderSelfHosted. It returns
+// ProviderUnknown and an error if a lookup fails.
+func (v *Validator) LookupProvider(ctx context.Context, domain string) (Provider, error) {
+	normalized, err := v.NormalizeDomain(domain)
+	if err != nil {
+		return ProviderUnknown, err
+	}
+
+	mx, err := lookupMX(ctx, v.resolver(), normalized)
+	if err != nil {
+		return ProviderUnknown, err
+	}
+
+	return v.fingerprint(ctx, normalized, mx.Hosts)
+}
+
+// fingerprint identifies the provider from the MX hosts of an already normalized domain, looking up its SPF
+// record if no MX host matches.
+func (v *Validator) fingerprint(ctx context.Context, domain string, hosts []string) (Provider, error) {
+	for _, host := range hosts {
+		if provider := matchProvider(host, func(f providerFingerprint) []string { return f.mxHosts }); provider != ProviderUnknown {
+			return provider, nil
+		}
+	}
+
+	records, err := v.resolver().LookupTXT(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return ProviderUnknown, fmt.Errorf("looking up TXT records of %s: %w", domain, err)
+	}
+
+	for _, include := range spfIncludes(records) {
+		if provider := matchProvider(include, func(f providerFingerprint) []string { return f.spfIncludes }); provider != ProviderUnknown {
+			return provider, nil
+		}
+	}
+
+	for _, host := range hosts {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return ProviderSelfHosted, nil
+		}
+	}
+
+	return ProviderUnknown, nil
+}
+
+// matchProvider returns the provider with an entry matching the name or one of its parents.
+func matchProvider(name string, entries func(providerFingerprint) []string) Provider {
+	for suffix := range suffixes(name) {
+		for _, fingerprint := range providerFingerprints {
+			for _, entry := range entries(fingerprint) {
+				if suffix == entry {
+					return fingerprint.provider
+				}
+			}
+		}
+	}
+
+	return ProviderUnknown
+}
+
+// spfIncludes returns the domains of the include mechanisms in the SPF record among the TXT records.
+func spfIncludes(records []string) []string {
+	var includes []string
+
+	for _, record := range records {
+		fields := strings.Fields(strings.ToLower(record))
+		if len(fields) == 0 || fields[0] != "v=spf1" {
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			// A qualifier such as "?include:" or "~include:" may precede the mechanism.
+			field = strings.TrimLeft(field, "+-~?")
+			if include, ok := strings.CutPrefix(field, "include:"); ok {
+				includes = append(includes, strings.TrimSuffix(include, "."))
+			}
+		}
+	}
+
+	return includes
+}
+
+// LookupProvider identifies the mailbox provider hosting the domain's mail with the system resolver.
+func LookupProvider(ctx context.Context, domain string) (Provider, error) {
+	return defaultValidator.LookupProvider(ctx, domain)
+}
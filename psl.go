@@ -0,0 +1,128 @@
+package workemailvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPublicSuffix means the domain is itself a public suffix, such as "com" or "co.uk", so it has no
+// registrable domain.
+var ErrPublicSuffix = errors.New("domain is a public suffix")
+
+// publicSuffixList holds the rules of the Public Suffix List (https://publicsuffix.org), keyed by the
+// suffix they apply to. "*.ck" is stored as "ck" in wildcards and "!www.ck" as "www.ck" in exceptions.
+type publicSuffixList struct {
+	rules      map[string]struct{}
+	wildcards  map[string]struct{}
+	exceptions map[string]struct{}
+}
+
+// loadPublicSuffixList parses the Public Suffix List format: one rule per line, "//" comments, and only the
+// first whitespace-separated field of a line. Both the ICANN and the private sections are used. Rules are
+// converted to ASCII so they match normalized domains.
+func loadPublicSuffixList(data string) publicSuffixList {
+	list := publicSuffixList{
+		rules:      make(map[string]struct{}),
+		wildcards:  make(map[string]struct{}),
+		exceptions: make(map[string]struct{}),
+	}
+
+	for line := range strings.Lines(data) {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "//") {
+			continue
+		}
+
+		rule := strings.ToLower(fields[0])
+
+		switch {
+		case strings.HasPrefix(rule, "!"):
+			list.exceptions[domainToASCII(rule[1:])] = struct{}{}
+		case strings.HasPrefix(rule, "*."):
+			list.wildcards[domainToASCII(rule[2:])] = struct{}{}
+		default:
+			list.rules[domainToASCII(rule)] = struct{}{}
+		}
+	}
+
+	return list
+}
+
+// publicSuffix returns the public suffix of a normalized domain with the standard algorithm: the longest
+// matching rule wins, exception rules remove their leftmost label, and an unlisted top-level domain is
+// its own public suffix (the implicit "*" rule).
+func (l publicSuffixList) publicSuffix(domain string) string {
+	for suffix := range suffixes(domain) {
+		_, parent, hasParent := strings.Cut(suffix, ".")
+
+		if _, ok := l.exceptions[suffix]; ok && hasParent {
+			return parent
+		}
+
+		if _, ok := l.rules[suffix]; ok {
+			return suffix
+		}
+
+		if _, ok := l.wildcards[parent]; ok && hasParent {
+			return suffix
+		}
+	}
+
+	return domain[strings.LastIndexByte(domain, '.')+1:]
+}
+
+// isPublicSuffix reports whether the normalized domain is a public suffix, so a list entry for it would
+// match every domain registered under it.
+func (l publicSuffixList) isPublicSuffix(domain string) bool {
+	return l.publicSuffix(domain) == domain
+}
+
+// isTopLevelDomain reports whether the label is a top-level domain known to the list, including those only
+// listed through a wildcard rule such as "*.ck".
+func (l publicSuffixList) isTopLevelDomain(label string) bool {
+	_, rule := l.rules[label]
+	_, wildcard := l.wildcards[label]
+
+	return rule || wildcard
+}
+
+// registrableDomain returns the public suffix of a normalized domain plus one label (the eTLD+1), e.g.
+// "example.co.uk" for "mail.example.co.uk". It reports false if the domain is a public suffix.
+func (l publicSuffixList) registrableDomain(domain string) (string, bool) {
+	suffix := l.publicSuffix(domain)
+	if len(suffix) >= len(domain) {
+		return "", false
+	}
+
+	label := strings.LastIndexByte(domain[:len(domain)-len(suffix)-1], '.')
+
+	return domain[label+1:], true
+}
+
+// RegistrableDomain returns the registrable part of the domain according to the embedded Public Suffix
+// List: its public suffix plus one label, e.g. "example.co.uk" for "mail.example.co.uk" or
+// "alice.github.io" for "www.alice.github.io". The domain is normalized first. Domains that are public
+// suffixes themselves, such as "co.uk", fail with ErrPublicSuffix.
+func (v *Validator) RegistrableDomain(domain string) (string, error) {
+	normalized, err := v.NormalizeDomain(domain)
+	if err != nil {
+		return "", err
+	}
+
+	if !isValidDomainSyntax(normalized) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidSyntax, domain)
+	}
+
+	registrable, ok := publicSuffixes.registrableDomain(normalized)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrPublicSuffix, normalized)
+	}
+
+	return registrable, nil
+}
+
+// RegistrableDomain returns the registrable part of the domain according to the embedded Public Suffix List.
+func RegistrableDomain(domain string) (string, error) {
+	return defaultValidator.RegistrableDomain(domain)
+}
@@ -0,0 +1,301 @@
+package workemailvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultPollInterval is how often Run checks the list files for changes unless WithPollInterval is given.
+const defaultPollInterval = 30 * time.Second
+
+// ErrNoReloadFiles is returned by NewReloader when no list files are configured.
+var ErrNoReloadFiles = errors.New("no list files to reload")
+
+// ReloadStats reports how a successful reload changed the lists.
+type ReloadStats struct {
+	DisposableAdded   int
+	DisposableRemoved int
+	FreeAdded         int
+	FreeRemoved       int
+}
+
+// ReloaderOption configures a Reloader created by NewReloader.
+type ReloaderOption func(*Reloader)
+
+// Reloader keeps a Validator in sync with domain list files. Files are parsed off the hot path and the new
+// Validator is swapped in atomically, so concurrent callers of Validator always see complete lists.
+// A failed reload keeps the previous lists.
+type Reloader struct {
+	current atomic.Pointer[Validator]
+
+	validatorOpts   []Option
+	disposableFiles []string
+	freeFiles       []string
+	pollInterval    time.Duration
+	onReload        func(ReloadStats)
+	onError         func(error)
+
+	// mu serializes reloads and guards the fields below.
+	mu         sync.Mutex
+	disposable domainSet
+	free       domainSet
+	stamps     map[string]fileStamp
+}
+
+// fileStamp identifies a version of a list file for change detection.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// WithDisposableFiles reloads the disposable list from the given files, replacing the embedded list and, as with
+// WithDisposableDomains, the embedded name patterns.
+func WithDisposableFiles(names ...string) ReloaderOption {
+	return func(r *Reloader) {
+		r.disposableFiles = names
+	}
+}
+
+// WithFreeFiles reloads the free list from the given files, replacing the embedded list.
+func WithFreeFiles(names ...string) ReloaderOption {
+	return func(r *Reloader) {
+		r.freeFiles = names
+	}
+}
+
+// WithValidatorOptions applies the given options to every Validator the Reloader builds.
+func WithValidatorOptions(opts ...Option) ReloaderOption {
+	return func(r *Reloader) {
+		r.validatorOpts = opts
+	}
+}
+
+// WithPollInterval sets how often Run checks the list files for changes.
+func WithPollInterval(interval time.Duration) ReloaderOption {
+	return func(r *Reloader) {
+		r.pollInterval = interval
+	}
+}
+
+// WithReloadCallback is called after every successful reload, including the initial load.
+func WithReloadCallback(callback func(ReloadStats)) ReloaderOption {
+	return func(r *Reloader) {
+		r.onReload = callback
+	}
+}
+
+// WithErrorCallback is called when a reload triggered by Run fails.
+func WithErrorCallback(callback func(error)) ReloaderOption {
+	return func(r *Reloader) {
+		r.onError = callback
+	}
+}
+
+// NewReloader creates a Reloader and performs the initial load. It fails if no files are configured
+// or the initial load fails.
+func NewReloader(opts ...ReloaderOption) (*Reloader, error) {
+	reloader := &Reloader{
+		current:         atomic.Pointer[Validator]{},
+		validatorOpts:   nil,
+		disposableFiles: nil,
+		freeFiles:       nil,
+		pollInterval:    defaultPollInterval,
+		onReload:        nil,
+		onError:         nil,
+		mu:              sync.Mutex{},
+		disposable:      nil,
+		free:            nil,
+		stamps:          nil,
+	}
+
+	for _, opt := range opts {
+		opt(reloader)
+	}
+
+	if len(reloader.disposableFiles) == 0 && len(reloader.freeFiles) == 0 {
+		return nil, ErrNoReloadFiles
+	}
+
+	if err := reloader.Reload(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// Validator returns the current Validator. Callers should fetch it once per request
+// to get a consistent view of the lists.
+func (r *Reloader) Validator() *Validator {
+	return r.current.Load()
+}
+
+// Reload re-reads all list files and swaps in a new Validator. On error the current Validator is kept.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stamps, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	return r.reload(stamps)
+}
+
+// reload reads the list files and swaps in a new Validator. It must be called with mu held.
+func (r *Reloader) reload(stamps map[string]fileStamp) error {
+	opts := append([]Option(nil), r.validatorOpts...)
+
+	disposable, err := readListFiles(r.disposableFiles)
+	if err != nil {
+		return err
+	}
+
+	free, err := readListFiles(r.freeFiles)
+	if err != nil {
+		return err
+	}
+
+	if disposable != nil {
+		opts = append(opts, withDisposableSet(disposable))
+	}
+
+	if free != nil {
+		opts = append(opts, withFreeSet(free))
+	}
+
+	stats := ReloadStats{
+		DisposableAdded:   countMissing(disposable, r.disposable),
+		DisposableRemoved: countMissing(r.disposable, disposable),
+		FreeAdded:         countMissing(free, r.free),
+		FreeRemoved:       countMissing(r.free, free),
+	}
+
+	r.current.Store(New(opts...))
+	r.disposable, r.free, r.stamps = disposable, free, stamps
+
+	if r.onReload != nil {
+		r.onReload(stats)
+	}
+
+	return nil
+}
+
+// Run polls the list files every poll interval and reloads them when any file changed,
+// until the context is canceled. Reload errors are reported to the error callback.
+func (r *Reloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.reloadIfChanged(); err != nil && r.onError != nil {
+				r.onError(err)
+			}
+		}
+	}
+}
+
+// reloadIfChanged reloads the list files if any of them changed since the last successful reload.
+func (r *Reloader) reloadIfChanged() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stamps, err := r.stat()
+	if err != nil {
+		return err
+	}
+
+	if len(stamps) == len(r.stamps) {
+		changed := false
+
+		for name, stamp := range stamps {
+			if previous, ok := r.stamps[name]; !ok || !previous.modTime.Equal(stamp.modTime) || previous.size != stamp.size {
+				changed = true
+				break
+			}
+		}
+
+		if !changed {
+			return nil
+		}
+	}
+
+	return r.reload(stamps)
+}
+
+// stat records the modification time and size of every list file.
+func (r *Reloader) stat() (map[string]fileStamp, error) {
+	stamps := make(map[string]fileStamp, len(r.disposableFiles)+len(r.freeFiles))
+
+	for _, names := range [][]string{r.disposableFiles, r.freeFiles} {
+		for _, name := range names {
+			info, err := os.Stat(name)
+			if err != nil {
+				return nil, fmt.Errorf("checking domain list: %w", err)
+			}
+
+			stamps[name] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+	}
+
+	return stamps, nil
+}
+
+// readListFiles reads and merges the given list files into a set, dropping public suffixes.
+// It returns nil if no files are given.
+func readListFiles(names []string) (domainSet, error) {
+	if len(names) == 0 {
+		return nil, nil //nolint:nilnil // nil set means the list is not file-backed
+	}
+
+	set := make(domainSet)
+
+	for _, name := range names {
+		entries, err := ReadDomainsFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			set.add(entry)
+		}
+	}
+
+	return set, nil
+}
+
+// countMissing returns how many entries of a are not in b.
+func countMissing(a, b domainSet) int {
+	count := 0
+
+	for domain := range a {
+		if _, ok := b[domain]; !ok {
+			count++
+		}
+	}
+
+	return count
+}
+
+// withDisposableSet replaces the disposable list with an already normalized set, like WithDisposableDomains.
+func withDisposableSet(set domainSet) Option {
+	return func(v *Validator) {
+		v.replaceDisposableDomains(set)
+	}
+}
+
+// withFreeSet replaces the free list with an already normalized set.
+func withFreeSet(set domainSet) Option {
+	return func(v *Validator) {
+		v.freeDomains = set
+	}
+}
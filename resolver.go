@@ -0,0 +1,66 @@
+package workemailvalidator
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+)
+
+// Resolver looks up the DNS records used by the MX checks and provider fingerprinting. *net.Resolver satisfies it.
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// StaticResolver is a Resolver backed by fixed records, for tests and offline use.
+// Names are matched case-insensitively, with or without a trailing dot.
+// Names missing from a map fail with a not-found *net.DNSError.
+type StaticResolver struct {
+	// MX maps a domain to its MX records.
+	MX map[string][]*net.MX
+	// Hosts maps a host name to its A and AAAA addresses.
+	Hosts map[string][]string
+	// TXT maps a name to its TXT records, e.g. the SPF policy of a domain.
+	TXT map[string][]string
+	// Errors maps a name to an error returned by every lookup of that name, e.g. a timeout.
+	Errors map[string]error
+}
+
+// LookupMX returns the MX records of the name.
+func (r *StaticResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	return staticLookup(r.MX, r.Errors, name)
+}
+
+// LookupHost returns the addresses of the host.
+func (r *StaticResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	return staticLookup(r.Hosts, r.Errors, host)
+}
+
+// LookupTXT returns the TXT records of the name.
+func (r *StaticResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	return staticLookup(r.TXT, r.Errors, name)
+}
+
+// staticLookup finds the name in the records, returning a configured error or a not-found error.
+func staticLookup[T any](records map[string][]T, errs map[string]error, name string) ([]T, error) {
+	key := strings.TrimSuffix(strings.ToLower(name), ".")
+
+	if err, ok := errs[key]; ok {
+		return nil, err
+	}
+
+	if values, ok := records[key]; ok {
+		return values, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+// isNotFound reports whether the error means the name or record does not exist.
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
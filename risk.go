@@ -0,0 +1,266 @@
+package workemailvalidator
+
+import (
+	"context"
+	"math"
+)
+
+const (
+	// maxRiskScore is the highest risk score; the sum of the contributions is capped to it.
+	maxRiskScore = 100
+	// fullAgreementSources is the number of feeds from which a disposable entry gets the full source agreement weight.
+	fullAgreementSources = 3
+)
+
+// Signal is a risk signal that contributes to the risk score.
+type Signal int
+
+const (
+	// SignalInvalid means the email address or its domain is not valid.
+	SignalInvalid Signal = iota
+	// SignalDisposable means the domain is disposable: on the disposable list or the blocklist.
+	SignalDisposable
+	// SignalSourceAgreement grows with the number of feeds that reported the disposable entry.
+	SignalSourceAgreement
+	// SignalConfusable means the domain spoofs a free or disposable domain with look-alike characters.
+	SignalConfusable
+	// SignalFree means the domain belongs to a free email provider.
+	SignalFree
+	// SignalRelay means the domain belongs to an email relay/privacy-alias service.
+	SignalRelay
+	// SignalTypo means the domain is likely mistyped: full strength for a typo of a free provider, half for
+	// another correction such as an unknown top-level domain.
+	SignalTypo
+	// SignalRole means the address is a shared role account such as "info@".
+	SignalRole
+	// SignalRandomLocalPart means the local part looks machine-generated, e.g. "xk29fjq7".
+	SignalRandomLocalPart
+	// SignalNoMailServer means the DNS checks found that the domain does not accept mail.
+	SignalNoMailServer
+	// SignalDisposableMX means the DNS checks found that the domain's mail servers are disposable infrastructure.
+	SignalDisposableMX
+	// SignalRandomDomain means a domain that is on no list has a machine-generated name, e.g. "qzvkhtrw.xyz".
+	SignalRandomDomain
+	// SignalRiskyTLD means a domain that is on no list sits on a top-level domain popular with throwaway domains:
+	// full strength for TLDRiskHigh, half for TLDRiskElevated.
+	SignalRiskyTLD
+)
+
+// String returns the lowercase name of the signal.
+func (s Signal) String() string {
+	switch s {
+	case SignalInvalid:
+		return "invalid"
+	case SignalDisposable:
+		return "disposable"
+	case SignalSourceAgreement:
+		return "source_agreement"
+	case SignalConfusable:
+		return "confusable"
+	case SignalFree:
+		return "free"
+	case SignalRelay:
+		return "relay"
+	case SignalTypo:
+		return "typo"
+	case SignalRole:
+		return "role"
+	case SignalRandomLocalPart:
+		return "random_local_part"
+	case SignalNoMailServer:
+		return "no_mail_server"
+	case SignalDisposableMX:
+		return "disposable_mx"
+	case SignalRandomDomain:
+		return "random_domain"
+	case SignalRiskyTLD:
+		return "risky_tld"
+	default:
+		return "unknown"
+	}
+}
+
+// defaultRiskWeights are the points each signal adds to the risk score at full strength.
+var defaultRiskWeights = map[Signal]float64{
+	SignalInvalid:         100,
+	SignalDisposable:      80,
+	SignalSourceAgreement: 20,
+	SignalConfusable:      90,
+	SignalFree:            30,
+	SignalRelay:           20,
+	SignalTypo:            40,
+	SignalRole:            15,
+	SignalRandomLocalPart: 25,
+	SignalNoMailServer:    70,
+	SignalDisposableMX:    80,
+	SignalRandomDomain:    20,
+	SignalRiskyTLD:        20,
+}
+
+// RiskContribution is the part of a risk score contributed by one signal.
+type RiskContribution struct {
+	// Signal is the signal that fired.
+	Signal Signal
+	// Strength is how strongly the signal fired, from 0 to 1.
+	Strength float64
+	// Points is the signal's weight multiplied by its strength.
+	Points float64
+}
+
+// RiskScore is the outcome of ScoreRisk.
+type RiskScore struct {
+	// Score is the sum of the contributions, rounded and capped to 0-100. Higher is riskier.
+	Score int
+	// Contributions lists the signals that fired with a non-zero weight, in Signal order.
+	Contributions []RiskContribution
+	// Result is the classification of the address the signals were derived from.
+	Result Result
+}
+
+// ScoreRisk combines the signals known about the email address into a risk score from 0 to 100, with the
+// contribution of each signal: disposable and free membership, feed agreement, spoofed and mistyped domains,
+// role accounts, machine-generated local parts and domains, and risky top-level domains. Each signal adds its
+// weight multiplied by its strength; use WithRiskWeights to change the weights. Invalid addresses score 100.
+func (v *Validator) ScoreRisk(email string) RiskScore {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return v.riskScore(invalidResult(""), "")
+	}
+
+	result := v.Explain(address.Domain)
+	result.Role = v.isRoleLocalPart(address.Local)
+
+	return v.riskScore(result, address.Local)
+}
+
+// ScoreRiskContext is ScoreRisk with the DNS checks of ExplainContext, if enabled when building the validator.
+// Domains that do not accept mail or use disposable mail infrastructure add SignalNoMailServer or SignalDisposableMX.
+func (v *Validator) ScoreRiskContext(ctx context.Context, email string) RiskScore {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return v.riskScore(invalidResult(""), "")
+	}
+
+	result := v.ExplainContext(ctx, address.Domain)
+	result.Role = v.isRoleLocalPart(address.Local)
+
+	return v.riskScore(result, address.Local)
+}
+
+// riskScore weighs the signals of the classified address.
+func (v *Validator) riskScore(result Result, local string) RiskScore {
+	score := RiskScore{Score: 0, Contributions: nil, Result: result}
+	total := 0.0
+
+	for _, contribution := range v.riskSignals(result, local) {
+		contribution.Points = v.riskWeights[contribution.Signal] * contribution.Strength
+		if contribution.Points == 0 {
+			continue
+		}
+
+		score.Contributions = append(score.Contributions, contribution)
+		total += contribution.Points
+	}
+
+	score.Score = int(math.Round(min(max(total, 0), maxRiskScore)))
+
+	return score
+}
+
+// riskSignals returns the signals that fired for the classified address, in Signal order, without points.
+func (v *Validator) riskSignals(result Result, local string) []RiskContribution {
+	var signals []RiskContribution
+
+	fire := func(signal Signal, strength float64) {
+		if strength > 0 {
+			signals = append(signals, RiskContribution{Signal: signal, Strength: strength, Points: 0})
+		}
+	}
+
+	if result.Category == CategoryInvalid {
+		fire(SignalInvalid, 1)
+		return signals
+	}
+
+	// A spoof scores as a spoof rather than as the domain it imitates, and disposable MX hosts as a DNS finding.
+	switch {
+	case result.List == ListDisposable:
+		fire(SignalDisposable, 1)
+		fire(SignalSourceAgreement, float64(min(v.sourceCount(result.Match), fullAgreementSources))/fullAgreementSources)
+	case result.List == ListConfusable:
+		fire(SignalConfusable, 1)
+	case result.Category == CategoryDisposable && result.List != ListDisposableMX:
+		fire(SignalDisposable, 1)
+	}
+
+	if result.Category == CategoryFree && result.List == ListFree {
+		fire(SignalFree, 1)
+	}
+
+	if result.Category == CategoryRelay {
+		fire(SignalRelay, 1)
+	}
+
+	fire(SignalTypo, v.typoStrength(result))
+
+	if result.Role {
+		fire(SignalRole, 1)
+	}
+
+	fire(SignalRandomLocalPart, localPartRandomness(local))
+
+	if result.Category == CategoryNoMail {
+		fire(SignalNoMailServer, 1)
+	}
+
+	if result.List == ListDisposableMX {
+		fire(SignalDisposableMX, 1)
+	}
+
+	// Listed domains are already judged by their list, whatever their names look like.
+	if result.List == ListNone {
+		fire(SignalRandomDomain, domainRandomness(result.Domain))
+		fire(SignalRiskyTLD, tldRiskStrength(result.TLDRisk))
+	}
+
+	return signals
+}
+
+// typoStrength returns the strength of SignalTypo: 1 for a typo of a free provider and 0.5 for another
+// correction of a business domain, such as an unknown top-level domain.
+func (v *Validator) typoStrength(result Result) float64 {
+	switch {
+	case result.List == ListFreeTypo:
+		return 1
+	case result.Category != CategoryBusiness && result.Category != CategoryNoMail:
+		return 0
+	}
+
+	suggestion, ok := v.suggest(result.Domain)
+
+	switch {
+	case !ok:
+		return 0
+	case contains(suggestion, v.freeDomains):
+		return 1
+	default:
+		return 0.5 //nolint:mnd // half strength for corrections that are not free providers
+	}
+}
+
+// tldRiskStrength returns the strength of SignalRiskyTLD for the tier.
+func tldRiskStrength(risk TLDRisk) float64 {
+	switch risk {
+	case TLDRiskHigh:
+		return 1
+	case TLDRiskElevated:
+		return 0.5 //nolint:mnd // half strength for TLDs that also host many legitimate domains
+	default:
+		return 0
+	}
+}
+
+// ScoreRisk combines the signals known about the email address into a risk score from 0 to 100.
+func ScoreRisk(email string) RiskScore {
+	return defaultValidator.ScoreRisk(email)
+}
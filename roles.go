@@ -0,0 +1,75 @@
+package workemailvalidator
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrRoleAccount means the email address is a shared role account such as "info@" or "noreply@".
+var ErrRoleAccount = errors.New("role account")
+
+// loadRoleAccounts parses the embedded role account list.
+func loadRoleAccounts(data string) map[string]struct{} {
+	var entries []string
+
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		entries = append(entries, line)
+	}
+
+	return newRoleSet(entries)
+}
+
+// newRoleSet normalizes role account local parts into a set, skipping empty entries.
+func newRoleSet(entries []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(entries))
+
+	for _, entry := range entries {
+		if local := normalizeLocalPart(entry); local != "" {
+			set[local] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+// normalizeLocalPart prepares a local part for role matching: removes the quotes of a quoted local part,
+// lowercases, drops a "+tag" subaddress and removes the separators ".", "-" and "_".
+func normalizeLocalPart(local string) string {
+	local = strings.TrimSpace(local)
+	if len(local) >= 2 && local[0] == '"' && local[len(local)-1] == '"' {
+		local = unquote(local)
+	}
+
+	local, _, _ = strings.Cut(strings.ToLower(local), "+")
+
+	return strings.NewReplacer(".", "", "-", "", "_", "").Replace(local)
+}
+
+// isRoleLocalPart reports whether the local part is on the role account list.
+func (v *Validator) isRoleLocalPart(local string) bool {
+	_, ok := v.roleAccounts[normalizeLocalPart(local)]
+
+	return ok
+}
+
+// IsRoleAccount reports whether the email address is a shared role account, such as "info@", "sales@" or
+// "no-reply@", rather than a person's mailbox. Case, "+tag" subaddresses and the separators ".", "-" and "_"
+// are ignored. Addresses that do not parse are not role accounts.
+func (v *Validator) IsRoleAccount(email string) bool {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return false
+	}
+
+	return v.isRoleLocalPart(address.Local)
+}
+
+// IsRoleAccount reports whether the email address is a shared role account.
+func IsRoleAccount(email string) bool {
+	return defaultValidator.IsRoleAccount(email)
+}
@@ -2,7 +2,9 @@
 
 /**
  * Domain Lists Update Script
- * Downloads and merges disposable and free email domain lists from multiple sources
+ * Downloads and merges disposable and free email domain lists from multiple sources,
+ * refreshes the embedded Public Suffix List and Unicode confusables data, and extracts the
+ * universities whose domains are not under an academic suffix
  */
 
 import { readFileSync, writeFileSync } from "fs";
@@ -16,22 +18,43 @@ interface Source {
   url: string;
 }
 
+interface DisposableSource extends Source {
+  id: string;
+}
+
 interface Config {
-  disposable_sources: Source[];
+  disposable_sources: DisposableSource[];
   free_source: Source;
+  public_suffix_source: Source;
+  confusables_source: Source;
+  university_source: Source;
   exclude_domains: string[];
 }
 
+interface University {
+  name: string;
+  domains: string[];
+}
+
 interface ConflictResolutionResult {
   disposable: Set<string>;
   free: Set<string>;
   removed: number;
 }
 
+interface MergeResult {
+  domains: Set<string>;
+  provenance: Map<string, Set<string>>;
+}
+
 interface ProcessingResult {
   disposableDomains: Set<string>;
+  disposableProvenance: Map<string, Set<string>>;
   freeDomains: Set<string>;
   conflictsResolved: number;
+  publicSuffixList: string;
+  confusables: string;
+  universityDomains: Set<string>;
 }
 
 // ============================================================================
@@ -48,9 +71,22 @@ const ROOT_DIR = resolve(__dirname, "..");
 const CONFIG_FILE = resolve(ROOT_DIR, "config/repositories.json");
 const OUTPUT_PATHS = {
   disposable: resolve(ROOT_DIR, "data/disposable_domains.txt"),
+  disposableSources: resolve(ROOT_DIR, "data/disposable_sources.txt"),
   free: resolve(ROOT_DIR, "data/free_domains.txt"),
+  publicSuffixList: resolve(ROOT_DIR, "data/public_suffix_list.dat"),
+  confusables: resolve(ROOT_DIR, "data/confusables.txt"),
+  universities: resolve(ROOT_DIR, "data/university_domains.txt"),
 } as const;
 
+// Markers that every valid copy of the verbatim data files contains
+const PUBLIC_SUFFIX_MARKER = "===BEGIN ICANN DOMAINS===";
+const CONFUSABLES_MARKER = ";\tMA\t";
+
+// Labels the validator already detects as academic by rule: the "edu" top-level domain,
+// and "ac" or "edu" directly under a two-letter country code (e.g. "ox.ac.uk")
+const ACADEMIC_TLDS = new Set(["edu"]);
+const ACADEMIC_SECOND_LEVELS = new Set(["ac", "edu"]);
+
 // ANSI color codes
 const COLORS = {
   reset: "\x1b[0m",
@@ -159,7 +195,7 @@ async function downloadSourceWithRetry(
 }
 
 async function downloadDisposableSources(
-  sources: Source[]
+  sources: DisposableSource[]
 ): Promise<Map<string, Set<string>>> {
   log.header("Downloading disposable domains from multiple sources...");
   
@@ -181,10 +217,10 @@ async function downloadDisposableSources(
     throw new Error(`Failed to download ${failures.length} of ${sources.length} disposable domain sources`);
   }
   
-  // Build results map
+  // Build results map keyed by source id
   const resultsMap = new Map<string, Set<string>>();
-  for (const result of results) {
-    resultsMap.set(result.source.name, new Set(result.domains));
+  for (const [index, result] of results.entries()) {
+    resultsMap.set(sources[index].id, new Set(result.domains));
   }
   
   return resultsMap;
@@ -197,23 +233,32 @@ function normalizeExcludeDomains(excludeDomains: string[]): Set<string> {
 function mergeDomains(
   sourcesMap: Map<string, Set<string>>,
   excludeDomains: string[]
-): Set<string> {
+): MergeResult {
   log.header("Merging and deduplicating disposable domains...");
   
   const excludeSet = normalizeExcludeDomains(excludeDomains);
   log.info(`  Excluding ${excludeDomains.length} domains: ${excludeDomains.join(", ")}`);
   
   const merged = new Set<string>();
+  const provenance = new Map<string, Set<string>>();
   
-  for (const [_name, domains] of sourcesMap) {
+  for (const [sourceId, domains] of sourcesMap) {
     for (const domain of domains) {
-      if (!excludeSet.has(domain)) {
-        merged.add(domain);
+      if (excludeSet.has(domain)) {
+        continue;
       }
+      merged.add(domain);
+      
+      let reportedBy = provenance.get(domain);
+      if (reportedBy === undefined) {
+        reportedBy = new Set<string>();
+        provenance.set(domain, reportedBy);
+      }
+      reportedBy.add(sourceId);
     }
   }
   
-  return merged;
+  return { domains: merged, provenance };
 }
 
 async function downloadFreeDomains(source: Source): Promise<Set<string>> {
@@ -236,6 +281,96 @@ async function downloadFreeDomains(source: Source): Promise<Set<string>> {
   }
 }
 
+async function downloadVerbatimFile(source: Source, marker: string): Promise<string> {
+  log.header(`Downloading ${source.name}...`);
+  console.log(`  Source: ${source.name}`);
+  console.log(`  URL: ${source.url}`);
+  
+  try {
+    const response = await fetch(source.url);
+    if (!response.ok) {
+      throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+    }
+    
+    const text = await response.text();
+    if (!text.includes(marker)) {
+      throw new Error(`Downloaded file is missing the ${JSON.stringify(marker)} marker`);
+    }
+    
+    const entries = text
+      .split("\n")
+      .map((line) => line.trim())
+      .filter((line) => line && !line.startsWith("//") && !line.startsWith("#"));
+    
+    log.success(`Downloaded ${entries.length} entries`);
+    return text;
+  } catch (error: unknown) {
+    log.error(`Failed to download ${source.name}: ${formatError(error)}`);
+    throw new Error(`Critical: ${source.name} download failed - ${formatError(error)}`);
+  }
+}
+
+function isAcademicSuffix(domain: string): boolean {
+  const labels = domain.split(".");
+  const tld = labels[labels.length - 1];
+  if (ACADEMIC_TLDS.has(tld)) {
+    return true;
+  }
+  return labels.length >= 3 && tld.length === 2 && ACADEMIC_SECOND_LEVELS.has(labels[labels.length - 2]);
+}
+
+function isCoveredByParent(domain: string, domains: Set<string>): boolean {
+  const labels = domain.split(".");
+  for (let i = 1; i < labels.length - 1; i++) {
+    if (domains.has(labels.slice(i).join("."))) {
+      return true;
+    }
+  }
+  return false;
+}
+
+async function downloadUniversityDomains(source: Source): Promise<Set<string>> {
+  log.header("Downloading university domains...");
+  console.log(`  Source: ${source.name}`);
+  console.log(`  URL: ${source.url}`);
+  
+  try {
+    const response = await fetch(source.url);
+    if (!response.ok) {
+      throw new Error(`HTTP ${response.status}: ${response.statusText}`);
+    }
+    
+    const universities = (await response.json()) as University[];
+    const all = new Set<string>();
+    for (const university of universities) {
+      for (const domain of university.domains ?? []) {
+        const normalized = domain.trim().toLowerCase().replace(/\.$/, "");
+        if (normalized.includes(".")) {
+          all.add(normalized);
+        }
+      }
+    }
+    
+    if (all.size === 0) {
+      throw new Error("Downloaded file is empty or contains no valid domains");
+    }
+    
+    // Domains under an academic suffix are detected by rule, and subdomains match their parent's entry
+    const domains = new Set<string>();
+    for (const domain of all) {
+      if (!isAcademicSuffix(domain) && !isCoveredByParent(domain, all)) {
+        domains.add(domain);
+      }
+    }
+    
+    log.success(`Downloaded ${all.size} university domains, kept ${domains.size} outside academic suffixes`);
+    return domains;
+  } catch (error: unknown) {
+    log.error(`Failed to download university domains: ${formatError(error)}`);
+    throw new Error(`Critical: University domains download failed - ${formatError(error)}`);
+  }
+}
+
 function findConflicts(disposable: Set<string>, free: Set<string>): string[] {
   const conflicts: string[] = [];
   for (const domain of free) {
@@ -278,7 +413,7 @@ function createFileHeader(sources: string[], lastUpdated: string): string[] {
   ];
 }
 
-function createDisposableHeader(sources: Source[]): string[] {
+function createDisposableHeader(sources: DisposableSource[]): string[] {
   return createFileHeader(
     [
       "# Disposable Email Domains",
@@ -289,6 +424,18 @@ function createDisposableHeader(sources: Source[]): string[] {
   );
 }
 
+function createSourcesHeader(sources: DisposableSource[]): string[] {
+  return createFileHeader(
+    [
+      "# Disposable Email Domain Sources",
+      "# Format: <domain> <source-id>[,<source-id>...]",
+      "# Source ids:",
+      ...sources.map((s) => `#   - ${s.id}: ${s.name}`),
+    ],
+    formatTimestamp()
+  );
+}
+
 function createFreeHeader(source: Source): string[] {
   return createFileHeader(
     [
@@ -299,6 +446,18 @@ function createFreeHeader(source: Source): string[] {
   );
 }
 
+function createUniversityHeader(source: Source): string[] {
+  return createFileHeader(
+    [
+      "# University Domains",
+      `# Source: ${source.name}`,
+      "# Universities whose domains are not under an academic suffix such as .edu or ac.uk,",
+      "# which are detected by rule. Subdomains match as well (e.g. inf.ethz.ch).",
+    ],
+    formatTimestamp()
+  );
+}
+
 function writeDomainsFile(
   filename: string,
   domains: Set<string>,
@@ -309,6 +468,22 @@ function writeDomainsFile(
   writeFileSync(filename, content, "utf-8");
 }
 
+function writeSourcesFile(
+  filename: string,
+  domains: Set<string>,
+  provenance: Map<string, Set<string>>,
+  header: string[]
+): void {
+  const lines = Array.from(domains)
+    .sort()
+    .map((domain) => {
+      const sourceIds = Array.from(provenance.get(domain) ?? []).sort();
+      return `${domain} ${sourceIds.join(",")}`;
+    });
+  const content = [...header, "", ...lines].join("\n") + "\n";
+  writeFileSync(filename, content, "utf-8");
+}
+
 function loadConfig(): Config {
   try {
     const configContent = readFileSync(CONFIG_FILE, "utf-8");
@@ -322,6 +497,9 @@ function loadConfig(): Config {
 function logConfigSummary(config: Config): void {
   log.success(`Loaded ${config.disposable_sources.length} disposable sources`);
   log.success(`Loaded 1 free email source`);
+  log.success(`Loaded 1 public suffix source`);
+  log.success(`Loaded 1 confusables source`);
+  log.success(`Loaded 1 university source`);
   log.success(`Loaded ${config.exclude_domains.length} domains to exclude`);
 }
 
@@ -331,11 +509,12 @@ async function processDomainsData(config: Config): Promise<ProcessingResult> {
     config.disposable_sources
   );
   
-  // Merge and deduplicate
-  let disposableDomains = mergeDomains(
+  // Merge and deduplicate, keeping which sources reported each domain
+  const merged = mergeDomains(
     disposableSourcesMap,
     config.exclude_domains
   );
+  let disposableDomains = merged.domains;
   
   log.success(
     `Disposable domains (before filtering): ${disposableDomains.size} unique domains`
@@ -351,10 +530,21 @@ async function processDomainsData(config: Config): Promise<ProcessingResult> {
   
   log.success(`Free domains: ${freeDomains.size} domains (after filtering)`);
   
+  // Download the Public Suffix List and the confusables data, written verbatim
+  const publicSuffixList = await downloadVerbatimFile(config.public_suffix_source, PUBLIC_SUFFIX_MARKER);
+  const confusables = await downloadVerbatimFile(config.confusables_source, CONFUSABLES_MARKER);
+  
+  // Download the university list, keeping only domains the academic suffix rules miss
+  const universityDomains = await downloadUniversityDomains(config.university_source);
+  
   return {
     disposableDomains,
+    disposableProvenance: merged.provenance,
     freeDomains,
     conflictsResolved: result.removed,
+    publicSuffixList,
+    confusables,
+    universityDomains,
   };
 }
 
@@ -365,14 +555,29 @@ function writeOutputFiles(
   const disposableHeader = createDisposableHeader(config.disposable_sources);
   writeDomainsFile(OUTPUT_PATHS.disposable, result.disposableDomains, disposableHeader);
   
+  const sourcesHeader = createSourcesHeader(config.disposable_sources);
+  writeSourcesFile(
+    OUTPUT_PATHS.disposableSources,
+    result.disposableDomains,
+    result.disposableProvenance,
+    sourcesHeader
+  );
+  
   const freeHeader = createFreeHeader(config.free_source);
   writeDomainsFile(OUTPUT_PATHS.free, result.freeDomains, freeHeader);
+  
+  writeFileSync(OUTPUT_PATHS.publicSuffixList, result.publicSuffixList, "utf-8");
+  writeFileSync(OUTPUT_PATHS.confusables, result.confusables, "utf-8");
+  
+  const universityHeader = createUniversityHeader(config.university_source);
+  writeDomainsFile(OUTPUT_PATHS.universities, result.universityDomains, universityHeader);
 }
 
 function printSummary(result: ProcessingResult, duration: string): void {
   log.header(`${SYMBOLS.done} Done! Lists updated successfully.`);
   console.log(`   Total disposable: ${result.disposableDomains.size}`);
   console.log(`   Total free: ${result.freeDomains.size}`);
+  console.log(`   Total universities: ${result.universityDomains.size}`);
   console.log(`   Conflicts resolved: ${result.conflictsResolved}`);
   console.log(`   Time taken: ${duration}s\n`);
 }
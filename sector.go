@@ -0,0 +1,128 @@
+package workemailvalidator
+
+import "strings"
+
+// Sector is the kind of organization behind a business domain.
+type Sector int
+
+const (
+	// SectorUnknown means the domain is not a business domain (invalid, disposable, free or a relay).
+	SectorUnknown Sector = iota
+	// SectorCorporate means the business domain matched no academic, government or military rule.
+	SectorCorporate
+	// SectorAcademic means the domain belongs to a university or school, e.g. ".edu", "ac.uk" or a listed university.
+	SectorAcademic
+	// SectorGovernment means the domain belongs to a government body, e.g. ".gov", "gov.uk" or "gouv.fr".
+	SectorGovernment
+	// SectorMilitary means the domain belongs to the military, e.g. ".mil".
+	SectorMilitary
+)
+
+// String returns the lowercase name of the sector.
+func (s Sector) String() string {
+	switch s {
+	case SectorUnknown:
+		return "unknown"
+	case SectorCorporate:
+		return "corporate"
+	case SectorAcademic:
+		return "academic"
+	case SectorGovernment:
+		return "government"
+	case SectorMilitary:
+		return "military"
+	default:
+		return "unknown"
+	}
+}
+
+// sectorTLDs are the generic top-level domains reserved for a sector.
+var sectorTLDs = map[string]Sector{
+	"edu": SectorAcademic,
+	"gov": SectorGovernment,
+	"mil": SectorMilitary,
+}
+
+// sectorSecondLevels are the second-level labels used for a sector under country-code top-level domains,
+// e.g. "ac" in "ox.ac.uk" or "gouv" in "interieur.gouv.fr".
+var sectorSecondLevels = map[string]Sector{
+	"ac":   SectorAcademic,
+	"edu":  SectorAcademic,
+	"gov":  SectorGovernment,
+	"govt": SectorGovernment,
+	"gouv": SectorGovernment,
+	"gob":  SectorGovernment,
+	"go":   SectorGovernment,
+	"gv":   SectorGovernment,
+	"mil":  SectorMilitary,
+}
+
+// sectorDomains are government and military domains that do not follow the suffix rules.
+// Subdomains match as well.
+var sectorDomains = map[string]Sector{
+	"admin.ch":  SectorGovernment,
+	"bund.de":   SectorGovernment,
+	"canada.ca": SectorGovernment,
+	"europa.eu": SectorGovernment,
+	"fed.us":    SectorGovernment,
+	"gc.ca":     SectorGovernment,
+	"mod.uk":    SectorMilitary,
+}
+
+// sector returns the sector of a normalized business domain.
+func (v *Validator) sector(domain string) Sector {
+	_, universityDepth, university := v.universityDomains.match(domain)
+	depth := 0
+
+	for suffix := range suffixes(domain) {
+		if university && depth == universityDepth {
+			return SectorAcademic
+		}
+
+		if sector, ok := sectorDomains[suffix]; ok {
+			return sector
+		}
+
+		depth++
+	}
+
+	labels := strings.Split(domain, ".")
+	tld := labels[len(labels)-1]
+
+	if sector, ok := sectorTLDs[tld]; ok {
+		return sector
+	}
+
+	// Second-level rules only apply under two-letter country codes, and need a name below them:
+	// "ox.ac.uk" is academic but "foo.ac" (Ascension Island) is not.
+	const countryCodeLength = 2
+	if len(labels) >= 3 && len(tld) == countryCodeLength {
+		if sector, ok := sectorSecondLevels[labels[len(labels)-2]]; ok {
+			return sector
+		}
+	}
+
+	return SectorCorporate
+}
+
+// ClassifySector returns the kind of organization behind a business domain: academic (".edu", "ac.uk", listed
+// universities), government (".gov", "gov.uk", "gouv.fr", ...), military (".mil") or corporate.
+// Domains that are not business domains return SectorUnknown.
+func (v *Validator) ClassifySector(domain string) Sector {
+	return v.Explain(domain).Sector
+}
+
+// ClassifySectorEmail returns the sector of the domain of the given email address.
+func (v *Validator) ClassifySectorEmail(email string) Sector {
+	return v.ExplainEmail(email).Sector
+}
+
+// ClassifySector returns the kind of organization behind a business domain.
+func ClassifySector(domain string) Sector {
+	return defaultValidator.ClassifySector(domain)
+}
+
+// ClassifySectorEmail returns the sector of the domain of the given email address.
+func ClassifySectorEmail(email string) Sector {
+	return defaultValidator.ClassifySectorEmail(email)
+}
@@ -0,0 +1,405 @@
+package workemailvalidator
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultProbeTimeout bounds each SMTP session unless WithProbeTimeout is given.
+	defaultProbeTimeout = 10 * time.Second
+	// defaultHelloName is sent in EHLO unless WithHelloName is given.
+	defaultHelloName = "localhost"
+	// smtpPort is the port MX hosts accept mail on.
+	smtpPort = "25"
+)
+
+// ErrProbeFailed is wrapped by the error returned from Probe when the MX lookup failed or no MX host gave an answer.
+var ErrProbeFailed = errors.New("smtp probe failed")
+
+// Deliverability is the outcome of an SMTP mailbox probe.
+type Deliverability int
+
+const (
+	// DeliverabilityUnknown means the server gave no definite answer, e.g. it was unreachable, blocked the
+	// probe by policy or greylisted it.
+	DeliverabilityUnknown Deliverability = iota
+	// Deliverable means the server accepted the recipient and permanently rejected a random one.
+	Deliverable
+	// Undeliverable means the server rejected the recipient or the domain does not accept mail.
+	Undeliverable
+	// CatchAll means the server accepted both the recipient and a random one, so the mailbox may not exist.
+	CatchAll
+)
+
+// String returns the lowercase name of the deliverability.
+func (d Deliverability) String() string {
+	switch d {
+	case DeliverabilityUnknown:
+		return "unknown"
+	case Deliverable:
+		return "deliverable"
+	case Undeliverable:
+		return "undeliverable"
+	case CatchAll:
+		return "catch_all"
+	default:
+		return "unknown"
+	}
+}
+
+// ProbeResult is the outcome of Probe.
+type ProbeResult struct {
+	// Deliverability is the verdict on the mailbox.
+	Deliverability Deliverability
+	// Host is the MX host that answered, or empty if none did.
+	Host string
+	// Code and Message are the server's reply to RCPT TO, or zero and empty if it was not sent.
+	Code    int
+	Message string
+	// Greylisted reports that the server deferred the recipient with a temporary failure.
+	Greylisted bool
+}
+
+// ProberOption configures a Prober created by NewProber.
+type ProberOption func(*Prober)
+
+// Prober verifies mailboxes over SMTP: it connects to the domain's MX hosts and issues EHLO, MAIL FROM and
+// RCPT TO without sending any data, then checks a random local part to detect catch-all servers.
+// Many networks block outbound port 25 and many servers rate-limit probes, so use it sparingly.
+type Prober struct {
+	validator       *Validator
+	helloName       string
+	mailFrom        string
+	timeout         time.Duration
+	tlsConfig       *tls.Config
+	greylistRetry   time.Duration
+	plainFallback   bool
+	dial            func(ctx context.Context, network, address string) (net.Conn, error)
+	randomLocalPart func() string
+}
+
+// WithProbeValidator resolves MX hosts with the validator's resolver and IDNA profile instead of the defaults.
+func WithProbeValidator(validator *Validator) ProberOption {
+	return func(p *Prober) {
+		p.validator = validator
+	}
+}
+
+// WithHelloName sets the host name sent in EHLO. Servers may reject probes from names that do not resolve.
+func WithHelloName(name string) ProberOption {
+	return func(p *Prober) {
+		p.helloName = name
+	}
+}
+
+// WithMailFrom sets the reverse path sent in MAIL FROM. The default is the null reverse path "<>".
+func WithMailFrom(address string) ProberOption {
+	return func(p *Prober) {
+		p.mailFrom = address
+	}
+}
+
+// WithProbeTimeout bounds each SMTP session, from connecting to QUIT.
+func WithProbeTimeout(timeout time.Duration) ProberOption {
+	return func(p *Prober) {
+		p.timeout = timeout
+	}
+}
+
+// WithProbeTLSConfig sets the TLS configuration used for STARTTLS. ServerName defaults to the MX host.
+func WithProbeTLSConfig(config *tls.Config) ProberOption {
+	return func(p *Prober) {
+		p.tlsConfig = config
+	}
+}
+
+// WithGreylistRetry retries a recipient deferred with a temporary failure once, after the given delay.
+func WithGreylistRetry(delay time.Duration) ProberOption {
+	return func(p *Prober) {
+		p.greylistRetry = delay
+	}
+}
+
+// WithPlaintextFallback retries a session in plain text when the STARTTLS handshake fails, e.g. because the
+// server's certificate is not trusted. The recipient is then sent unencrypted, so it is off by default and a
+// failed handshake counts as a host that did not answer.
+func WithPlaintextFallback() ProberOption {
+	return func(p *Prober) {
+		p.plainFallback = true
+	}
+}
+
+// WithProbeDialer sets the function used to connect to MX hosts, e.g. to go through a proxy.
+// It is called with the network "tcp" and the address "<host>:25".
+func WithProbeDialer(dial func(ctx context.Context, network, address string) (net.Conn, error)) ProberOption {
+	return func(p *Prober) {
+		p.dial = dial
+	}
+}
+
+// WithCatchAllLocalPart sets the function that returns the local part of the second recipient used to detect
+// catch-all servers, e.g. to make probes reproducible. The default is a random string that almost certainly
+// does not exist.
+func WithCatchAllLocalPart(localPart func() string) ProberOption {
+	return func(p *Prober) {
+		p.randomLocalPart = localPart
+	}
+}
+
+// NewProber creates a Prober with the given options.
+func NewProber(opts ...ProberOption) *Prober {
+	dialer := &net.Dialer{} //nolint:exhaustruct // zero dialer uses the system defaults
+
+	prober := &Prober{
+		validator:       defaultValidator,
+		helloName:       defaultHelloName,
+		mailFrom:        "",
+		timeout:         defaultProbeTimeout,
+		tlsConfig:       nil,
+		greylistRetry:   0,
+		plainFallback:   false,
+		dial:            dialer.DialContext,
+		randomLocalPart: randomLocalPart,
+	}
+
+	for _, opt := range opts {
+		opt(prober)
+	}
+
+	return prober
+}
+
+// Probe checks whether the mailbox of the email address exists. MX hosts are tried in order of preference
+// until one answers; STARTTLS is used when offered, and a host whose handshake fails is skipped unless
+// WithPlaintextFallback is given. Domains that do not accept mail are Undeliverable. If the MX lookup fails or
+// no host answers, Probe returns DeliverabilityUnknown and an error wrapping ErrProbeFailed.
+func (p *Prober) Probe(ctx context.Context, email string) (ProbeResult, error) {
+	unknown := ProbeResult{Deliverability: DeliverabilityUnknown, Host: "", Code: 0, Message: "", Greylisted: false}
+
+	address, err := ParseAddress(email)
+	if err != nil {
+		return unknown, err
+	}
+
+	mx, err := p.validator.LookupMX(ctx, address.Domain)
+	if err != nil {
+		return unknown, fmt.Errorf("%w: %w", ErrProbeFailed, err)
+	}
+
+	if !mx.Status.AcceptsMail() {
+		unknown.Deliverability = Undeliverable
+		return unknown, nil
+	}
+
+	recipient := address.String()
+	errs := make([]error, 0, len(mx.Hosts))
+
+	for _, host := range mx.Hosts {
+		result, err := p.probeHost(ctx, host, recipient, address.Domain)
+		if err == nil {
+			return result, nil
+		}
+
+		errs = append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return unknown, fmt.Errorf("%w: %w", ErrProbeFailed, errors.Join(errs...))
+}
+
+// probeHost probes the recipient on one MX host, retrying once after the greylist delay if it was deferred.
+func (p *Prober) probeHost(ctx context.Context, host, recipient, domain string) (ProbeResult, error) {
+	result, err := p.session(ctx, host, recipient, domain)
+	if err != nil || !result.Greylisted || p.greylistRetry <= 0 {
+		return result, err
+	}
+
+	timer := time.NewTimer(p.greylistRetry)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return result, nil
+	case <-timer.C:
+	}
+
+	return p.session(ctx, host, recipient, domain)
+}
+
+// session runs one SMTP session against the host with STARTTLS if offered and, if the handshake failed and
+// WithPlaintextFallback was given, once more without it.
+func (p *Prober) session(ctx context.Context, host, recipient, domain string) (ProbeResult, error) {
+	result, err := p.dialSession(ctx, host, recipient, domain, true)
+
+	var tlsErr *startTLSError
+	if p.plainFallback && errors.As(err, &tlsErr) {
+		return p.dialSession(ctx, host, recipient, domain, false)
+	}
+
+	return result, err
+}
+
+// startTLSError marks a failed STARTTLS handshake, after which the session may be retried in plain text.
+type startTLSError struct {
+	err error
+}
+
+func (e *startTLSError) Error() string {
+	return "starttls: " + e.err.Error()
+}
+
+func (e *startTLSError) Unwrap() error {
+	return e.err
+}
+
+// dialSession connects to the host and checks the recipient and a random local part of the domain.
+func (p *Prober) dialSession(ctx context.Context, host, recipient, domain string, useTLS bool) (ProbeResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	conn, err := p.dial(ctx, "tcp", net.JoinHostPort(host, smtpPort))
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("connecting to %s: %w", host, err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	// Unblock pending reads and writes when the context is canceled before the deadline.
+	stop := context.AfterFunc(ctx, func() { _ = conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return ProbeResult{}, fmt.Errorf("greeting from %s: %w", host, err)
+	}
+
+	defer client.Close() //nolint:errcheck // the session is over either way
+
+	result, err := p.converse(client, host, recipient, domain, useTLS)
+	if err == nil {
+		_ = client.Quit()
+	}
+
+	return result, err
+}
+
+// converse runs the SMTP commands of a session and interprets the replies.
+func (p *Prober) converse(client *smtp.Client, host, recipient, domain string, useTLS bool) (ProbeResult, error) {
+	if err := client.Hello(p.helloName); err != nil {
+		return ProbeResult{}, fmt.Errorf("EHLO to %s: %w", host, err)
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); ok && useTLS {
+		if err := client.StartTLS(p.tlsConfigFor(host)); err != nil {
+			return ProbeResult{}, &startTLSError{err: fmt.Errorf("%s: %w", host, err)}
+		}
+	}
+
+	if err := client.Mail(p.mailFrom); err != nil {
+		return ProbeResult{}, fmt.Errorf("MAIL FROM to %s: %w", host, err)
+	}
+
+	result := ProbeResult{Deliverability: DeliverabilityUnknown, Host: host, Code: 0, Message: "", Greylisted: false}
+
+	code, message, err := rcpt(client, recipient)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("RCPT TO to %s: %w", host, err)
+	}
+
+	result.Code, result.Message = code, message
+
+	switch {
+	case code/100 == 4:
+		result.Greylisted = true
+		return result, nil
+	case code/100 == 5:
+		if isMailboxRejection(code, message) {
+			result.Deliverability = Undeliverable
+		}
+
+		return result, nil
+	}
+
+	code, _, err = rcpt(client, p.randomLocalPart()+"@"+domain)
+	if err != nil {
+		return ProbeResult{}, fmt.Errorf("RCPT TO to %s: %w", host, err)
+	}
+
+	// A deferred random recipient leaves the verdict open.
+	switch code / 100 {
+	case 2:
+		result.Deliverability = CatchAll
+	case 5:
+		result.Deliverability = Deliverable
+	}
+
+	return result, nil
+}
+
+// tlsConfigFor returns the STARTTLS configuration for the host.
+func (p *Prober) tlsConfigFor(host string) *tls.Config {
+	if p.tlsConfig == nil {
+		return &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12} //nolint:exhaustruct // defaults for the rest
+	}
+
+	config := p.tlsConfig.Clone()
+	if config.ServerName == "" {
+		config.ServerName = host
+	}
+
+	return config
+}
+
+// rcpt sends RCPT TO and returns the reply. Only I/O and protocol failures are returned as errors.
+func rcpt(client *smtp.Client, recipient string) (int, string, error) {
+	err := client.Rcpt(recipient)
+	if err == nil {
+		return 250, "", nil //nolint:mnd // net/smtp only accepts 25x replies, so report the common one
+	}
+
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return protoErr.Code, protoErr.Msg, nil
+	}
+
+	return 0, "", err //nolint:wrapcheck // wrapped by the caller with the host
+}
+
+// isMailboxRejection reports whether a permanent RCPT failure means the mailbox does not exist, rather than a
+// policy block such as a blocklisted client address. Enhanced status codes (RFC 3463) decide when present:
+// 5.1.x are addressing failures and 5.7.x are policy failures.
+func isMailboxRejection(code int, message string) bool {
+	switch {
+	case strings.HasPrefix(message, "5.1."):
+		return true
+	case strings.HasPrefix(message, "5.7."):
+		return false
+	}
+
+	return code == 550 || code == 551 || code == 553
+}
+
+// randomLocalPart returns a local part that almost certainly does not exist, for catch-all detection.
+func randomLocalPart() string {
+	return strings.ToLower(rand.Text())
+}
+
+// Probe checks whether the mailbox of the email address exists using a Prober with the default settings.
+func Probe(ctx context.Context, email string) (ProbeResult, error) {
+	return NewProber().Probe(ctx, email)
+}
@@ -0,0 +1,52 @@
+package workemailvalidator
+
+import "slices"
+
+// sourceCount returns how many sources reported the disposable entry.
+// Entries without recorded provenance count as a single source, since at least one feed listed them.
+func (v *Validator) sourceCount(entry string) int {
+	if ids, ok := v.disposableSources[entry]; ok && len(ids) > 0 {
+		return len(ids)
+	}
+
+	return 1
+}
+
+// agreedDisposableDomains returns the disposable entries reported by at least minSources sources.
+// Entries without recorded provenance and exemptions are kept, since there is no feed count to judge them by.
+func (v *Validator) agreedDisposableDomains() domainSet {
+	if v.minSources <= 1 {
+		return v.disposableDomains
+	}
+
+	agreed := make(domainSet, len(v.disposableDomains))
+
+	for entry, scope := range v.disposableDomains {
+		if scope&scopeTree == 0 || len(v.disposableSources[entry]) == 0 || v.sourceCount(entry) >= v.minSources {
+			agreed[entry] = scope
+		}
+	}
+
+	return agreed
+}
+
+// Sources returns the ids of the feeds that reported the disposable entry matching the domain or its closest parent.
+// Ids refer to config/repositories.json. It returns nil if no entry matches or no provenance is recorded for it.
+func (v *Validator) Sources(domain string) []string {
+	normalized, err := v.normalize(domain)
+	if err != nil {
+		return nil
+	}
+
+	match, _, ok := v.disposableDomains.match(normalized)
+	if !ok {
+		return nil
+	}
+
+	return slices.Clone(v.disposableSources[match])
+}
+
+// Sources returns the ids of the feeds that reported the disposable entry matching the domain or its closest parent.
+func Sources(domain string) []string {
+	return defaultValidator.Sources(domain)
+}
@@ -0,0 +1,365 @@
+package workemailvalidator
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+const (
+	// adjacentKeyCost is the cost of substituting a key with one next to it on a QWERTY keyboard,
+	// the most common kind of typo.
+	adjacentKeyCost = 0.5
+	// maxTypoDistance is the largest edit distance of a suggestion from a popular provider.
+	maxTypoDistance = 2
+	// minLongDomainLength is the domain length from which maxTypoDistance applies; shorter domains
+	// and the full free list allow a distance of 1.
+	minLongDomainLength = 10
+	// minFreeTypoLength is the shortest domain checked against the full free list. Short domains such as
+	// "146.com" are a single edit away from many others.
+	minFreeTypoLength = 8
+	// minTypoLabelLength is the shortest first label for which any single edit counts as a typo. Shorter labels,
+	// such as "ge" in "ge.com", are a single edit away from real companies as well as from "me.com", so only
+	// slips, a swapped pair of characters or a neighboring key, count for them.
+	minTypoLabelLength = 5
+	// minSlipLabelLength is the shortest first label that is corrected at all. Two-letter names such as "ms.com"
+	// are slips of "me.com" and "qq.com" as often as they are companies.
+	minSlipLabelLength = 3
+)
+
+// popularProviders are the free providers most users type; typos of these are checked with a looser distance.
+var popularProviders = []string{
+	"gmail.com", "yahoo.com", "hotmail.com", "outlook.com", "icloud.com", "aol.com", "live.com", "msn.com",
+	"me.com", "mac.com", "protonmail.com", "proton.me", "gmx.com", "gmx.de", "gmx.net", "web.de", "mail.com",
+	"yandex.ru", "mail.ru", "qq.com", "163.com", "hotmail.co.uk", "yahoo.co.uk", "googlemail.com", "zoho.com",
+}
+
+// popularTLDs are the top-level domains a mistyped, unknown top-level domain is corrected to.
+var popularTLDs = []string{"com", "net", "org", "edu", "gov", "io", "co", "de", "uk", "fr", "ru", "nl", "info", "biz"}
+
+// keyboardRows lays out a QWERTY keyboard, with the horizontal offset of each row.
+var keyboardRows = []struct {
+	keys   string
+	offset float64
+}{
+	{"1234567890-", 0},
+	{"qwertyuiop", 0.5}, //nolint:mnd // row stagger in key widths
+	{"asdfghjkl", 0.75}, //nolint:mnd // row stagger in key widths
+	{"zxcvbnm", 1.25},   //nolint:mnd // row stagger in key widths
+}
+
+// SuggestDomain returns the domain a likely mistyped domain was meant to be, e.g. "gmail.com" for "gmial.com",
+// and reports whether it found one. Unknown top-level domains are corrected to a popular one ("acme.con" to
+// "acme.com"), then the domain is compared with the free providers by edit distance, where transposed
+// characters and neighboring keys count as typos. Internationalized spoofs of free providers, such as
+// "gm\u0430il.com" with a Cyrillic "a", are mapped back to the provider. Free providers and allowlisted domains
+// are never corrected; disposable domains are, since many are registered typos of popular providers.
+func (v *Validator) SuggestDomain(domain string) (string, bool) {
+	normalized, err := v.normalize(domain)
+	if err != nil || !isValidDomainSyntax(normalized) {
+		return "", false
+	}
+
+	return v.suggest(normalized)
+}
+
+// Suggest returns the email address with a likely mistyped domain corrected, e.g. "jane@gmail.com" for
+// "jane@gmial.com", and reports whether it found a correction. The display name is dropped.
+func (v *Validator) Suggest(email string) (string, bool) {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return "", false
+	}
+
+	domain, ok := v.SuggestDomain(address.Domain)
+	if !ok {
+		return "", false
+	}
+
+	return address.Local + "@" + domain, true
+}
+
+// suggest corrects a normalized, syntactically valid domain.
+func (v *Validator) suggest(domain string) (string, bool) {
+	if v.isCorrect(domain) {
+		return "", false
+	}
+
+	if match, category, _, ok := v.confusable(domain); ok && category == CategoryFree {
+		return match, true
+	}
+
+	corrected := correctTLD(domain)
+	if corrected != domain && v.isCorrect(corrected) {
+		return corrected, true
+	}
+
+	label, _, _ := strings.Cut(corrected, ".")
+	candidates := slices.Values(popularProviders)
+
+	switch {
+	case len(label) < minSlipLabelLength:
+		candidates = slices.Values([]string(nil))
+	case len(label) < minTypoLabelLength:
+		candidates = slipsOf(corrected, candidates)
+	}
+
+	if match, ok := closestDomain(corrected, candidates, maxDistanceFor(corrected)); ok {
+		return match, true
+	}
+
+	if len(corrected) >= minFreeTypoLength && len(label) >= minTypoLabelLength {
+		if match, ok := closestEdit(corrected, v.freeDomains); ok {
+			return match, true
+		}
+	}
+
+	if corrected == domain {
+		return "", false
+	}
+
+	return corrected, true
+}
+
+// freeTypo returns the free provider a normalized, unlisted domain is a likely typo of, if typo detection is enabled.
+func (v *Validator) freeTypo(domain string) (string, bool) {
+	if !v.typoCheck || !isValidDomainSyntax(domain) {
+		return "", false
+	}
+
+	suggestion, ok := v.suggest(domain)
+
+	return suggestion, ok && contains(suggestion, v.freeDomains)
+}
+
+// isCorrect reports whether the domain is known to be intended: allowlisted, or a free provider or relay
+// service or a subdomain of one.
+func (v *Validator) isCorrect(domain string) bool {
+	matches := v.matchLists(domain)
+	list, _, _ := matches.override()
+
+	return list == ListAllowlist || matches.free.ok || matches.relay.ok
+}
+
+// maxDistanceFor returns the largest edit distance accepted for a typo of a popular provider.
+func maxDistanceFor(domain string) float64 {
+	if len(domain) >= minLongDomainLength {
+		return maxTypoDistance
+	}
+
+	return 1
+}
+
+// slipsOf returns the candidates that the domain is a slip of: the same name with a swapped pair of adjacent
+// characters or one key replaced by a neighboring key.
+func slipsOf(domain string, candidates iter.Seq[string]) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for candidate := range candidates {
+			if isSlip(domain, candidate) && !yield(candidate) {
+				return
+			}
+		}
+	}
+}
+
+// isSlip reports whether a and b differ by a single swap of adjacent characters or a single neighboring key.
+func isSlip(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	first := 0
+	for first < len(a) && a[first] == b[first] {
+		first++
+	}
+
+	switch {
+	case first == len(a):
+		return false
+	case a[first+1:] == b[first+1:]:
+		return keyboardAdjacent(a[first], b[first])
+	default:
+		return first+1 < len(a) && a[first] == b[first+1] && a[first+1] == b[first] && a[first+2:] == b[first+2:]
+	}
+}
+
+// correctTLD replaces a top-level domain missing from the public suffix list with the closest popular one,
+// e.g. "con" with "com". Known top-level domains are kept.
+func correctTLD(domain string) string {
+	dot := strings.LastIndexByte(domain, '.')
+	tld := domain[dot+1:]
+
+	if publicSuffixes.isTopLevelDomain(tld) {
+		return domain
+	}
+
+	if match, ok := closestDomain(tld, slices.Values(popularTLDs), 1); ok {
+		return domain[:dot+1] + match
+	}
+
+	return domain
+}
+
+// closestDomain returns the candidate with the smallest edit distance to the domain, if within maxDistance.
+// Ties go to the lexically smaller candidate. An exact match is not a typo and returns false.
+func closestDomain(domain string, candidates iter.Seq[string], maxDistance float64) (string, bool) {
+	best, bestDistance := "", maxDistance+1
+
+	for candidate := range candidates {
+		if abs(len(candidate)-len(domain)) > int(maxDistance) {
+			continue
+		}
+
+		distance := editDistance(domain, candidate, maxDistance)
+
+		switch {
+		case distance == 0:
+			return "", false
+		case distance > maxDistance:
+			continue
+		}
+
+		if distance < bestDistance || distance == bestDistance && candidate < best {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	return best, best != ""
+}
+
+// domainAlphabet holds the characters of normalized domains, for generating single edits.
+const domainAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789-."
+
+// closestEdit returns the domain in the set closest to the domain among those a single deletion, insertion,
+// substitution or transposition away. Generating the edits and looking them up is much faster than comparing
+// the domain with every entry of a large set.
+func closestEdit(domain string, set domainSet) (string, bool) {
+	best, bestDistance := "", 2.0
+	buffer := make([]byte, 0, len(domain)+1)
+
+	check := func(candidate []byte) {
+		if !set.listed(string(candidate)) {
+			return
+		}
+
+		match := string(candidate)
+		if distance := editDistance(domain, match, 1); distance < bestDistance || distance == bestDistance && match < best {
+			best, bestDistance = match, distance
+		}
+	}
+
+	for i := 0; i <= len(domain); i++ {
+		if i < len(domain) {
+			check(append(append(buffer[:0], domain[:i]...), domain[i+1:]...))
+		}
+
+		if i+1 < len(domain) && domain[i] != domain[i+1] {
+			buffer = append(append(buffer[:0], domain[:i]...), domain[i+1], domain[i])
+			check(append(buffer, domain[i+2:]...))
+		}
+
+		for j := range len(domainAlphabet) {
+			char := domainAlphabet[j]
+
+			if i < len(domain) && char != domain[i] {
+				buffer = append(append(buffer[:0], domain[:i]...), char)
+				check(append(buffer, domain[i+1:]...))
+			}
+
+			buffer = append(append(buffer[:0], domain[:i]...), char)
+			check(append(buffer, domain[i:]...))
+		}
+	}
+
+	return best, best != ""
+}
+
+// editDistance is the optimal string alignment distance between a and b: insertions, deletions and
+// transpositions of adjacent characters cost 1, and substitutions cost 1, or adjacentKeyCost for
+// neighboring keys. It stops early and returns a value above maxDistance once the distance must exceed it.
+func editDistance(a, b string, maxDistance float64) float64 {
+	previous2 := make([]float64, len(b)+1)
+	previous := make([]float64, len(b)+1)
+	current := make([]float64, len(b)+1)
+
+	for j := range previous {
+		previous[j] = float64(j)
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = float64(i)
+		rowMin := current[0]
+
+		for j := 1; j <= len(b); j++ {
+			substitution := 0.0
+			if a[i-1] != b[j-1] {
+				substitution = 1
+				if keyboardAdjacent(a[i-1], b[j-1]) {
+					substitution = adjacentKeyCost
+				}
+			}
+
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+substitution)
+
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+
+			rowMin = min(rowMin, current[j])
+		}
+
+		// A transposition looks back two rows, so only stop once the previous row is out of range too.
+		if rowMin > maxDistance && min(slices.Min(previous), rowMin) > maxDistance {
+			return rowMin
+		}
+
+		previous2, previous, current = previous, current, previous2
+	}
+
+	return previous[len(b)]
+}
+
+// keyboardAdjacent reports whether the keys of two characters touch on a QWERTY keyboard.
+func keyboardAdjacent(a, b byte) bool {
+	ax, ay, aok := keyPosition(a)
+	bx, by, bok := keyPosition(b)
+
+	if !aok || !bok {
+		return false
+	}
+
+	dx, dy := ax-bx, ay-by
+
+	// Neighbors in a row are 1 apart and in the next row at most 0.75 apart horizontally.
+	return dx*dx+dy*dy <= 1.6 //nolint:mnd // squared distance between touching keys
+}
+
+// keyPosition returns the position of the character's key in key widths.
+func keyPosition(char byte) (float64, float64, bool) {
+	for row, layout := range keyboardRows {
+		if column := strings.IndexByte(layout.keys, char); column >= 0 {
+			return float64(column) + layout.offset, float64(row), true
+		}
+	}
+
+	return 0, 0, false
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+// SuggestDomain returns the domain a likely mistyped domain was meant to be.
+func SuggestDomain(domain string) (string, bool) {
+	return defaultValidator.SuggestDomain(domain)
+}
+
+// Suggest returns the email address with a likely mistyped domain corrected.
+func Suggest(email string) (string, bool) {
+	return defaultValidator.Suggest(email)
+}
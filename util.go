@@ -1,14 +1,92 @@
 package workemailvalidator
 
-import "golang.org/x/net/idna"
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// IDNAProfile selects the IDNA rules used to convert internationalized domain names to ASCII.
+type IDNAProfile int
+
+const (
+	// IDNAPunycode only applies Punycode encoding. It is the most lenient profile and the default.
+	IDNAPunycode IDNAProfile = iota
+	// IDNALookup applies the UTS #46 rules for looking up domains, mapping case and compatibility characters.
+	IDNALookup
+	// IDNARegistration applies the strictest rules, meant for registering domains.
+	IDNARegistration
+	// IDNADisplay applies the UTS #46 rules meant for displaying domains.
+	IDNADisplay
+)
+
+// String returns the lowercase name of the profile.
+func (p IDNAProfile) String() string {
+	switch p {
+	case IDNAPunycode:
+		return "punycode"
+	case IDNALookup:
+		return "lookup"
+	case IDNARegistration:
+		return "registration"
+	case IDNADisplay:
+		return "display"
+	default:
+		return "unknown"
+	}
+}
+
+// profile returns the idna package profile, falling back to Punycode for unknown values.
+func (p IDNAProfile) profile() *idna.Profile {
+	switch p {
+	case IDNALookup:
+		return idna.Lookup
+	case IDNARegistration:
+		return idna.Registration
+	case IDNADisplay:
+		return idna.Display
+	case IDNAPunycode:
+		return idna.Punycode
+	default:
+		return idna.Punycode
+	}
+}
+
+// toASCII converts an internationalized domain name to ASCII with the profile, reporting conversion errors.
+func (p IDNAProfile) toASCII(domain string) (string, error) {
+	asciiDomain, err := p.profile().ToASCII(domain)
+	if err != nil {
+		return "", fmt.Errorf("converting %q to ASCII with the %v profile: %w", domain, p, err)
+	}
+
+	return asciiDomain, nil
+}
 
 // domainToASCII converts any internationalized domain names to ASCII using Punycode.
 // Reference: https://en.wikipedia.org/wiki/Punycode
 func domainToASCII(domain string) string {
-	asciiDomain, err := idna.ToASCII(domain)
+	asciiDomain, err := IDNAPunycode.toASCII(domain)
 	if err != nil {
 		return domain
 	}
 
 	return asciiDomain
 }
+
+// NormalizeDomain returns the form of the domain used for lookups: trimmed, lowercased and converted to
+// ASCII with the validator's IDNA profile. Conversion failures are returned wrapping ErrIDNConversion,
+// regardless of strict mode.
+func (v *Validator) NormalizeDomain(domain string) (string, error) {
+	asciiDomain, err := v.idnaProfile.toASCII(strings.ToLower(strings.TrimSpace(domain)))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrIDNConversion, err)
+	}
+
+	return asciiDomain, nil
+}
+
+// NormalizeDomain returns the form of the domain used for lookups by the default validator.
+func NormalizeDomain(domain string) (string, error) {
+	return defaultValidator.NormalizeDomain(domain)
+}
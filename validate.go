@@ -0,0 +1,156 @@
+package workemailvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrInvalidSyntax means the email address or domain is not syntactically valid.
+	ErrInvalidSyntax = errors.New("invalid syntax")
+	// ErrIDNConversion means the internationalized domain could not be converted to ASCII.
+	ErrIDNConversion = errors.New("IDN conversion failed")
+	// ErrDisposable means the domain belongs to a disposable/temporary email service.
+	ErrDisposable = errors.New("disposable email domain")
+	// ErrFreeProvider means the domain belongs to a free email provider.
+	ErrFreeProvider = errors.New("free email provider")
+	// ErrBlocklisted means the domain matched the validator's blocklist. Its errors also match ErrDisposable.
+	ErrBlocklisted = errors.New("blocklisted domain")
+	// ErrRelay means the domain belongs to an email relay/privacy-alias service.
+	ErrRelay = errors.New("email relay service")
+)
+
+// ValidationError is returned by ValidateWorkEmail and ValidateBusinessDomain. It matches its Reason, the
+// sentinel of the domain's category and its underlying Err with errors.Is.
+type ValidationError struct {
+	// Input is the email address or domain as given.
+	Input string
+	// Reason is one of the Err* sentinels of this package.
+	Reason error
+	// Result is the classification of the domain. It is the zero Result if the input could not be parsed.
+	Result Result
+	// Err is the underlying parse or conversion error, or nil.
+	Err error
+}
+
+// Error returns the reason and, if present, the underlying error.
+func (e *ValidationError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%q: %v: %v", e.Input, e.Reason, e.Err)
+	}
+
+	return fmt.Sprintf("%q: %v", e.Input, e.Reason)
+}
+
+// Unwrap returns the reason and the underlying error so both match with errors.Is and errors.As. A reason
+// more specific than the domain's category, such as ErrBlocklisted, is followed by the category's sentinel,
+// ErrDisposable or ErrFreeProvider, so callers that only check the categories still catch it.
+func (e *ValidationError) Unwrap() []error {
+	errs := []error{e.Reason}
+
+	if category := categoryReason(e.Result.Category); category != nil && category != e.Reason {
+		errs = append(errs, category)
+	}
+
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+
+	return errs
+}
+
+// categoryReason returns the sentinel of a disposable or free category, or nil for other categories.
+func categoryReason(category Category) error {
+	switch category {
+	case CategoryDisposable:
+		return ErrDisposable
+	case CategoryFree:
+		return ErrFreeProvider
+	default:
+		return nil
+	}
+}
+
+// ValidateBusinessDomain returns nil if the domain is a business domain, or a *ValidationError
+// whose Reason tells why it is not.
+func (v *Validator) ValidateBusinessDomain(domain string) error {
+	return v.validateDomain(domain, domain)
+}
+
+// ValidateWorkEmail returns nil if the email address parses and its domain is a business domain,
+// or a *ValidationError whose Reason tells why it is not. Unlike IsWorkEmail, it reports domains that
+// fail IDN conversion with ErrIDNConversion instead of classifying the raw domain. With
+// WithRoleAccountRejection, role accounts on business domains fail with ErrRoleAccount.
+//
+//	switch err := v.ValidateWorkEmail(email); {
+//	case errors.Is(err, workemailvalidator.ErrFreeProvider):
+//		// ask for a company address
+//	case errors.Is(err, workemailvalidator.ErrDisposable):
+//		// reject
+//	}
+func (v *Validator) ValidateWorkEmail(email string) error {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return &ValidationError{Input: email, Reason: ErrInvalidSyntax, Result: Result{}, Err: err}
+	}
+
+	if err := v.validateDomain(email, address.Domain); err != nil {
+		return err
+	}
+
+	if v.rejectRoles && v.isRoleLocalPart(address.Local) {
+		return &ValidationError{Input: email, Reason: ErrRoleAccount, Result: v.ExplainEmail(email), Err: nil}
+	}
+
+	return nil
+}
+
+// validateDomain converts and classifies the domain, reporting failures against the original input.
+func (v *Validator) validateDomain(input, domain string) error {
+	normalized, err := v.idnaProfile.toASCII(strings.ToLower(strings.TrimSpace(domain)))
+	if err != nil {
+		return &ValidationError{Input: input, Reason: ErrIDNConversion, Result: Result{}, Err: err}
+	}
+
+	result := v.explain(normalized)
+	if reason := reasonFor(result); reason != nil {
+		return &ValidationError{Input: input, Reason: reason, Result: result, Err: nil}
+	}
+
+	return nil
+}
+
+// reasonFor maps a classification to the sentinel error reported for it, or nil for business domains.
+func reasonFor(result Result) error {
+	switch {
+	case result.Category == CategoryBusiness:
+		return nil
+	case result.Category == CategoryInvalid:
+		return ErrInvalidSyntax
+	case result.List == ListBlocklist:
+		return ErrBlocklisted
+	case result.List == ListDisposableMX:
+		return ErrDisposableMX
+	case result.List == ListConfusable:
+		return ErrConfusable
+	case result.Category == CategoryDisposable:
+		return ErrDisposable
+	case result.Category == CategoryNoMail:
+		return ErrNoMailServer
+	case result.Category == CategoryRelay:
+		return ErrRelay
+	default:
+		return ErrFreeProvider
+	}
+}
+
+// ValidateBusinessDomain returns nil if the domain is a business domain, or a *ValidationError.
+func ValidateBusinessDomain(domain string) error {
+	return defaultValidator.ValidateBusinessDomain(domain)
+}
+
+// ValidateWorkEmail returns nil if the email address is from a business domain, or a *ValidationError.
+func ValidateWorkEmail(email string) error {
+	return defaultValidator.ValidateWorkEmail(email)
+}
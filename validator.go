@@ -1,35 +1,128 @@
 // Package workemailvalidator provides email validation utilities to determine
-// whether an email address is from a disposable, free, or business domain.
+// whether an email address is from a disposable, free, relay, or business domain.
 package workemailvalidator
 
 import (
+	"iter"
 	"strings"
+	"sync"
 )
 
-// normalize prepares the domain for lookup: trims spaces, converts to ASCII (for IDN), and lowercases.
+// Validator checks domains and email addresses against its own disposable and free domain sets.
+// A Validator is immutable once built and safe for concurrent use.
+type Validator struct {
+	disposableDomains domainSet
+	disposableSources map[string][]string
+	disposableMX      mxRules
+	domainRules       domainRules
+	freeDomains       domainSet
+	relayDomains      domainSet
+	universityDomains domainSet
+	allowlist         domainSet
+	blocklist         domainSet
+	roleAccounts      map[string]struct{}
+	canonicalRules    map[string]CanonicalRule
+	confusables       *confusableIndex
+	riskWeights       map[Signal]float64
+	minSources        int
+	idnaProfile       IDNAProfile
+	strictIDN         bool
+	dnsResolver       Resolver
+	mxCheck           bool
+	disposableMXCheck bool
+	providerCheck     bool
+	typoCheck         bool
+	rejectRoles       bool
+}
+
+// defaultValidator backs the package-level functions and uses the embedded domain lists.
+var defaultValidator = New()
+
+// New creates a Validator that uses the embedded domain lists, modified by the given options.
+func New(opts ...Option) *Validator {
+	validator := &Validator{
+		disposableDomains: disposableDomains,
+		disposableSources: disposableSources,
+		disposableMX:      disposableMX,
+		domainRules:       domainRuleSet,
+		freeDomains:       freeDomains,
+		relayDomains:      relayDomains,
+		universityDomains: universityDomains,
+		allowlist:         nil,
+		blocklist:         nil,
+		roleAccounts:      roleAccounts,
+		canonicalRules:    canonicalRuleSet,
+		confusables:       &confusableIndex{once: sync.Once{}, skeletons: nil},
+		riskWeights:       defaultRiskWeights,
+		minSources:        0,
+		idnaProfile:       IDNAPunycode,
+		strictIDN:         false,
+		dnsResolver:       nil,
+		mxCheck:           false,
+		disposableMXCheck: false,
+		providerCheck:     false,
+		typoCheck:         false,
+		rejectRoles:       false,
+	}
+
+	for _, opt := range opts {
+		opt(validator)
+	}
+
+	validator.disposableDomains = validator.agreedDisposableDomains()
+
+	return validator
+}
+
+// normalize prepares the domain for lookup: trims spaces, lowercases, and converts to ASCII (for IDN).
+// Lowercasing first keeps Unicode case variants such as "MÜNCHEN.de" on the same Punycode form.
 func normalize(domain string) string {
-	domain = strings.TrimSpace(domain)
-	domain = domainToASCII(domain)
+	domain = strings.ToLower(strings.TrimSpace(domain))
 
-	return strings.ToLower(domain)
+	return domainToASCII(domain)
 }
 
-// contains checks if the domain or any of its parents exist in the map.
-// It expects the domain to be already normalized (lowercase, trimmed).
-func contains(domain string, domainMap map[string]struct{}) bool {
-	if _, ok := domainMap[domain]; ok {
-		return true
+// normalize prepares the domain for lookup using the validator's IDNA profile. If conversion fails,
+// strict validators return the error and others fall back to the lowercased raw domain.
+func (v *Validator) normalize(domain string) (string, error) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+
+	asciiDomain, err := v.idnaProfile.toASCII(domain)
+	if err != nil {
+		if v.strictIDN {
+			return "", err
+		}
+
+		return domain, nil
 	}
 
-	for i := range len(domain) {
-		if domain[i] == '.' {
-			if _, ok := domainMap[domain[i+1:]]; ok {
-				return true
+	return asciiDomain, nil
+}
+
+// suffixes yields the domain followed by each of its parent domains, from longest to shortest.
+// "a.b.com" yields "a.b.com", "b.com" and "com".
+func suffixes(domain string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if !yield(domain) {
+			return
+		}
+
+		for i := range len(domain) {
+			if domain[i] == '.' {
+				if !yield(domain[i+1:]) {
+					return
+				}
 			}
 		}
 	}
+}
+
+// contains checks if an entry of the set covers the domain, honoring exact, subdomain-only and exempting entries.
+// It expects the domain to be already normalized (lowercase, trimmed).
+func contains(domain string, set domainSet) bool {
+	_, _, ok := set.match(domain)
 
-	return false
+	return ok
 }
 
 // isValidDomainSyntax checks structure. Assumes domain is trimmed.
@@ -61,42 +154,91 @@ func isValidDomainSyntax(domain string) bool {
 	return true
 }
 
+// emailDomain extracts the domain of the email address. It reports false if the address does not parse.
+func emailDomain(email string) (string, bool) {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return "", false
+	}
+
+	return address.Domain, true
+}
+
+// IsDisposableDomain checks if the given domain is a disposable/temporary email domain, or spoofs one with
+// look-alike Unicode characters. Blocklisted domains are reported as disposable; allowlisted and relay domains are not.
+// It agrees with Classify, which decides domains on several lists.
+func (v *Validator) IsDisposableDomain(domain string) bool {
+	return v.category(domain) == CategoryDisposable
+}
+
+// IsFreeDomain checks if the given domain is a free email provider domain, spoofs one with look-alike Unicode
+// characters, or is a likely typo of one if typo detection is enabled. Allowlisted and blocklisted domains are
+// never reported as free, and neither are domains that Classify reports as disposable or relay.
+func (v *Validator) IsFreeDomain(domain string) bool {
+	return v.category(domain) == CategoryFree
+}
+
+// IsDisposableOrFreeDomain checks if the given domain is either disposable or free, as reported by Classify.
+func (v *Validator) IsDisposableOrFreeDomain(domain string) bool {
+	category := v.category(domain)
+
+	return category == CategoryDisposable || category == CategoryFree
+}
+
+// IsRelayDomain checks if the given domain is an email relay/privacy-alias service, such as Apple Hide My Email
+// or Firefox Relay. Relay addresses forward to a real mailbox, so they are neither disposable nor free.
+// Allowlisted and blocklisted domains are never reported as relays.
+func (v *Validator) IsRelayDomain(domain string) bool {
+	return v.category(domain) == CategoryRelay
+}
+
+// IsBusinessDomain checks if the given domain is neither disposable, free nor a relay service.
+func (v *Validator) IsBusinessDomain(domain string) bool {
+	return v.Classify(domain) == CategoryBusiness
+}
+
+// IsWorkEmail checks if the given email address is from a business domain.
+// The address is parsed with ParseAddress, so display names, quoted local parts and comments are accepted.
+// With WithRoleAccountRejection, role accounts such as "info@" are rejected as well.
+func (v *Validator) IsWorkEmail(email string) bool {
+	address, err := ParseAddress(email)
+	if err != nil {
+		return false
+	}
+
+	if v.rejectRoles && v.isRoleLocalPart(address.Local) {
+		return false
+	}
+
+	return v.IsBusinessDomain(address.Domain)
+}
+
 // IsDisposableDomain checks if the given domain is a disposable/temporary email domain.
 func IsDisposableDomain(domain string) bool {
-	return contains(normalize(domain), disposableDomains)
+	return defaultValidator.IsDisposableDomain(domain)
 }
 
 // IsFreeDomain checks if the given domain is a free email provider domain.
 func IsFreeDomain(domain string) bool {
-	return contains(normalize(domain), freeDomains)
+	return defaultValidator.IsFreeDomain(domain)
 }
 
 // IsDisposableOrFreeDomain checks if the given domain is either disposable or free.
 func IsDisposableOrFreeDomain(domain string) bool {
-	normalized := normalize(domain)
-	return contains(normalized, disposableDomains) || contains(normalized, freeDomains)
+	return defaultValidator.IsDisposableOrFreeDomain(domain)
+}
+
+// IsRelayDomain checks if the given domain is an email relay/privacy-alias service.
+func IsRelayDomain(domain string) bool {
+	return defaultValidator.IsRelayDomain(domain)
 }
 
 // IsBusinessDomain checks if the given domain is neither disposable nor free.
 func IsBusinessDomain(domain string) bool {
-	normalized := normalize(domain)
-
-	if !isValidDomainSyntax(normalized) {
-		return false
-	}
-
-	return !contains(normalized, disposableDomains) && !contains(normalized, freeDomains)
+	return defaultValidator.IsBusinessDomain(domain)
 }
 
 // IsWorkEmail checks if the given email address is from a business domain.
 func IsWorkEmail(email string) bool {
-	atIndex := strings.LastIndexByte(email, '@')
-
-	if atIndex <= 0 || atIndex >= len(email)-1 {
-		return false
-	}
-
-	domain := email[atIndex+1:]
-
-	return IsBusinessDomain(domain)
+	return defaultValidator.IsWorkEmail(email)
 }
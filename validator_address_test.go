@@ -0,0 +1,165 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestParseAddress tests parsing of valid RFC 5322 / RFC 6531 addresses.
+func TestParseAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected workemailvalidator.Address
+	}{
+		{"simple", "jane@corp.com", workemailvalidator.Address{Name: "", Local: "jane", Domain: "corp.com"}},
+		{"whitespace", "  jane @ corp.com  ", workemailvalidator.Address{Name: "", Local: "jane", Domain: "corp.com"}},
+		{"folding_whitespace", "jane@\r\n corp.com", workemailvalidator.Address{Name: "", Local: "jane", Domain: "corp.com"}},
+		{"atext", "a!#$%&'*+/=?^_`{|}~-b@corp.com", workemailvalidator.Address{Name: "", Local: "a!#$%&'*+/=?^_`{|}~-b", Domain: "corp.com"}},
+		{"dots", "first.middle.last@mail.corp.com", workemailvalidator.Address{Name: "", Local: "first.middle.last", Domain: "mail.corp.com"}},
+		{"quoted_local", `"a@b"@gmail.com`, workemailvalidator.Address{Name: "", Local: `"a@b"`, Domain: "gmail.com"}},
+		{"quoted_local_escapes", `"a\"b\\c d"@corp.com`, workemailvalidator.Address{Name: "", Local: `"a\"b\\c d"`, Domain: "corp.com"}},
+		{"comments", "(lead)jane(nested (comment))@(x)corp.com(trail)", workemailvalidator.Address{Name: "", Local: "jane", Domain: "corp.com"}},
+		{"display_name", "Jane Doe <jane@corp.com>", workemailvalidator.Address{Name: "Jane Doe", Local: "jane", Domain: "corp.com"}},
+		{"quoted_display_name", `"Doe, Jane \"JD\"" <jane@corp.com>`, workemailvalidator.Address{Name: `Doe, Jane "JD"`, Local: "jane", Domain: "corp.com"}},
+		{"obsolete_phrase", "John Q. Public <john@corp.com>", workemailvalidator.Address{Name: "John Q. Public", Local: "john", Domain: "corp.com"}},
+		{"angle_only", "<jane@corp.com>", workemailvalidator.Address{Name: "", Local: "jane", Domain: "corp.com"}},
+		{"angle_with_comment", "Jane <jane@corp.com> (work)", workemailvalidator.Address{Name: "Jane", Local: "jane", Domain: "corp.com"}},
+		{"quoted_angle_in_name", `"<Jane>" <jane@corp.com>`, workemailvalidator.Address{Name: "<Jane>", Local: "jane", Domain: "corp.com"}},
+		{"utf8_local", "用户@example.com", workemailvalidator.Address{Name: "", Local: "用户", Domain: "example.com"}}, //nolint:gosmopolitan
+		{"utf8_domain", "user@münchen.de", workemailvalidator.Address{Name: "", Local: "user", Domain: "münchen.de"}},
+		{"utf8_display_name", "Jürgen <j@corp.de>", workemailvalidator.Address{Name: "Jürgen", Local: "j", Domain: "corp.de"}},
+		{"single_label_domain", "a@b", workemailvalidator.Address{Name: "", Local: "a", Domain: "b"}},
+		{"max_local", strings.Repeat("a", 64) + "@corp.com", workemailvalidator.Address{Name: "", Local: strings.Repeat("a", 64), Domain: "corp.com"}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := workemailvalidator.ParseAddress(testCase.input)
+			if err != nil {
+				t.Fatalf("ParseAddress(%q) error = %v", testCase.input, err)
+			}
+
+			if address != testCase.expected {
+				t.Errorf("ParseAddress(%q) = %+v, want %+v", testCase.input, address, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestParseAddressErrors tests that invalid addresses are rejected with a descriptive error.
+func TestParseAddressErrors(t *testing.T) {
+	t.Parallel()
+
+	longDomain := strings.Repeat(strings.Repeat("d", 60)+".", 5) + "com"
+
+	tests := []struct {
+		name    string
+		input   string
+		message string
+	}{
+		{"empty", "", "empty address"},
+		{"whitespace_only", "  (comment) ", "empty address"},
+		{"no_at", "jane.corp.com", "missing '@'"},
+		{"no_local", "@corp.com", "missing local part"},
+		{"no_domain", "jane@", "missing domain"},
+		{"double_at", "jane@@corp.com", "missing domain"},
+		{"unquoted_at", "a@b@corp.com", `unexpected "@corp.com" after address`},
+		{"leading_dot", ".jane@corp.com", "local part starts with a dot"},
+		{"trailing_dot", "jane.@corp.com", "local part ends with a dot"},
+		{"consecutive_dots", "ja..ne@corp.com", "local part contains consecutive dots"},
+		{"domain_trailing_dot", "jane@corp.com.", "domain ends with a dot"},
+		{"domain_consecutive_dots", "jane@corp..com", "domain contains consecutive dots"},
+		{"domain_hyphen", "jane@-corp.com", "starts or ends with a hyphen"},
+		{"domain_underscore", "jane@co_rp.com", "invalid character '_' in domain"},
+		{"domain_long_label", "jane@" + strings.Repeat("a", 64) + ".com", "exceeds 63 octets"},
+		{"domain_literal", "jane@[192.0.2.1]", "domain literals are not supported"},
+		{"space_in_local", "ja ne@corp.com", `unexpected "n" in local part`},
+		{"space_in_domain", "jane@corp com", `unexpected "com" after address`},
+		{"unterminated_quote", `"jane@corp.com`, "unterminated quoted string"},
+		{"bad_escape", "\"ja\\\x01ne\"@corp.com", "invalid escape in quoted string"},
+		{"control_in_quote", "\"ja\x01ne\"@corp.com", "control character in quoted string"},
+		{"unterminated_comment", "jane(oops@corp.com", "unterminated comment"},
+		{"missing_angle_close", "Jane <jane@corp.com", "missing closing '>'"},
+		{"bad_display_name", "Jane, Doe <jane@corp.com>", `unexpected "," in display name`},
+		{"trailing_text", "jane@corp.com extra", `unexpected "extra" after address`},
+		{"list", "a@corp.com, b@corp.com", `unexpected ", b@corp.com" after address`},
+		{"local_too_long", strings.Repeat("a", 65) + "@corp.com", "local part exceeds 64 octets"},
+		{"address_too_long", "jane@" + longDomain, "address exceeds 254 octets"},
+		{"invalid_utf8", "jane\xff@corp.com", "invalid UTF-8"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			address, err := workemailvalidator.ParseAddress(testCase.input)
+			if err == nil {
+				t.Fatalf("ParseAddress(%q) = %+v, want error", testCase.input, address)
+			}
+
+			if !errors.Is(err, workemailvalidator.ErrInvalidAddress) {
+				t.Errorf("ParseAddress(%q) error = %v, want ErrInvalidAddress", testCase.input, err)
+			}
+
+			if !strings.Contains(err.Error(), testCase.message) {
+				t.Errorf("ParseAddress(%q) error = %q, want it to contain %q", testCase.input, err, testCase.message)
+			}
+		})
+	}
+}
+
+// TestAddressString tests the addr-spec form of a parsed address.
+func TestAddressString(t *testing.T) {
+	t.Parallel()
+
+	address, err := workemailvalidator.ParseAddress(`Jane (x) <"j d"(c)@ corp.com>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := address.String(), `"j d"@corp.com`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+// FuzzParseAddress tests that ParseAddress never panics and that parsed addresses round-trip.
+func FuzzParseAddress(f *testing.F) {
+	seeds := []string{
+		"jane@corp.com",
+		`"a@b"@gmail.com`,
+		"Jane <jane@corp.com>",
+		"(c)jane(d)@corp.com",
+		`"unterminated`,
+		"((nested)",
+		"<@>",
+		"\\",
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		address, err := workemailvalidator.ParseAddress(input)
+		if err != nil {
+			return
+		}
+
+		again, err := workemailvalidator.ParseAddress(address.String())
+		if err != nil {
+			t.Fatalf("ParseAddress(%q) = %q, which does not parse again: %v", input, address.String(), err)
+		}
+
+		if again.Local != address.Local || again.Domain != address.Domain {
+			t.Errorf("round trip of %q: got %+v, want %+v", input, again, address)
+		}
+	})
+}
@@ -221,3 +221,23 @@ func BenchmarkIsFreeDomain_Allocations(b *testing.B) {
 		workemailvalidator.IsFreeDomain("gmail.com")
 	}
 }
+
+// Benchmark single-pass classification.
+func BenchmarkClassify_Business(b *testing.B) {
+	for b.Loop() {
+		workemailvalidator.Classify("very.long.subdomain.that.is.not.disposable.example.com")
+	}
+}
+
+func BenchmarkClassifyEmail_Free(b *testing.B) {
+	for b.Loop() {
+		workemailvalidator.ClassifyEmail("user@mail.gmail.com")
+	}
+}
+
+// Benchmark typo suggestions, which check every single edit of the domain against the free list.
+func BenchmarkSuggestDomain(b *testing.B) {
+	for b.Loop() {
+		workemailvalidator.SuggestDomain("example-business.com")
+	}
+}
@@ -0,0 +1,93 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestCanonicalize tests the provider rules applied to address variants.
+func TestCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		email     string
+		canonical string
+	}{
+		{"gmail_dots_and_tag", "Jane.Doe+trial@gmail.com", "janedoe@gmail.com"},
+		{"googlemail_alias", "j.a.n.e@GoogleMail.com", "jane@gmail.com"},
+		{"outlook_tag", "jane.doe+news@outlook.com", "jane.doe@outlook.com"},
+		{"hotmail_kept", "Jane.Doe@Hotmail.com", "jane.doe@hotmail.com"},
+		{"yahoo_hyphen", "jane-shopping@yahoo.com", "jane@yahoo.com"},
+		{"yahoo_plus_kept", "jane+x@yahoo.com", "jane+x@yahoo.com"},
+		{"icloud_alias", "jane+x@me.com", "jane@icloud.com"},
+		{"proton_separators", "jane_doe.x-y@pm.me", "janedoexy@proton.me"},
+		{"business_tag", "Jane+crm@Corp.com", "jane@corp.com"},
+		{"business_dots_kept", "jane.doe@corp.com", "jane.doe@corp.com"},
+		{"leading_tag_kept", "+jane@corp.com", "+jane@corp.com"},
+		{"only_ignored_kept", "_-_@pm.me", "_-_@proton.me"},
+		{"display_name", "Jane <JANE@gmail.com>", "jane@gmail.com"},
+		{"quoted_plain", `"Jane.Doe"@gmail.com`, "janedoe@gmail.com"},
+		{"quoted_special", `"jane doe"@corp.com`, `"jane doe"@corp.com`},
+		{"idn_domain", "jane@münchen.de", "jane@xn--mnchen-3ya.de"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			canonical, err := workemailvalidator.Canonicalize(testCase.email)
+			if err != nil {
+				t.Fatalf("Canonicalize(%q) error = %v", testCase.email, err)
+			}
+
+			if canonical != testCase.canonical {
+				t.Errorf("Canonicalize(%q) = %q, want %q", testCase.email, canonical, testCase.canonical)
+			}
+		})
+	}
+}
+
+// TestCanonicalizeErrors tests that unparsable addresses and domains are reported.
+func TestCanonicalizeErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := workemailvalidator.Canonicalize("jane@"); !errors.Is(err, workemailvalidator.ErrInvalidAddress) {
+		t.Errorf("Canonicalize(jane@) error = %v, want ErrInvalidAddress", err)
+	}
+
+	if _, err := workemailvalidator.Canonicalize("jane@xn--zz.com"); !errors.Is(err, workemailvalidator.ErrIDNConversion) {
+		t.Errorf("Canonicalize(jane@xn--zz.com) error = %v, want ErrIDNConversion", err)
+	}
+}
+
+// TestWithCanonicalRules tests adding and overriding provider rules.
+func TestWithCanonicalRules(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithCanonicalRules(
+		workemailvalidator.CanonicalRule{Domains: []string{"Corp.com", "corp.net"}, Canonical: "corp.com", TagSeparators: "+-", IgnoredChars: "."},
+		workemailvalidator.CanonicalRule{Domains: []string{"gmail.com"}, Canonical: "", TagSeparators: "", IgnoredChars: ""},
+	))
+
+	tests := []struct {
+		email     string
+		canonical string
+	}{
+		{"Jane.Doe-sales@corp.net", "janedoe@corp.com"},
+		{"jane.doe+x@gmail.com", "jane.doe+x@gmail.com"},
+		{"jane.doe+x@googlemail.com", "janedoe@gmail.com"},
+	}
+
+	for _, testCase := range tests {
+		if canonical, err := validator.Canonicalize(testCase.email); err != nil || canonical != testCase.canonical {
+			t.Errorf("Canonicalize(%q) = %q, %v, want %q", testCase.email, canonical, err, testCase.canonical)
+		}
+	}
+
+	if canonical, _ := workemailvalidator.Canonicalize("jane.doe+x@gmail.com"); canonical != "janedoe@gmail.com" {
+		t.Errorf("package-level rules changed by instance options: %q", canonical)
+	}
+}
@@ -0,0 +1,140 @@
+package workemailvalidator_test
+
+import (
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestClassify tests the single-category domain classification.
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		domain   string
+		expected workemailvalidator.Category
+	}{
+		{"disposable", "temp-mail.com", workemailvalidator.CategoryDisposable},
+		{"disposable_subdomain", "a.b.temp-mail.com", workemailvalidator.CategoryDisposable},
+		{"free", "gmail.com", workemailvalidator.CategoryFree},
+		{"free_subdomain", "mail.gmail.com", workemailvalidator.CategoryFree},
+		{"free_uppercase", "  GMAIL.COM ", workemailvalidator.CategoryFree},
+		{"business", "example.com", workemailvalidator.CategoryBusiness},
+		{"business_idn", "münchen.de", workemailvalidator.CategoryBusiness},
+		{"empty", "", workemailvalidator.CategoryInvalid},
+		{"no_tld", "domain", workemailvalidator.CategoryInvalid},
+		{"short_tld", "domain.a", workemailvalidator.CategoryInvalid},
+		{"trailing_dot", "gmail.com.", workemailvalidator.CategoryInvalid},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := workemailvalidator.Classify(testCase.domain); got != testCase.expected {
+				t.Errorf("Classify(%q) = %v, want %v", testCase.domain, got, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestClassifyEmail tests classification of the domain part of an email address.
+func TestClassifyEmail(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		email    string
+		expected workemailvalidator.Category
+	}{
+		{"business", "user@example.com", workemailvalidator.CategoryBusiness},
+		{"free", "user@gmail.com", workemailvalidator.CategoryFree},
+		{"disposable", "user@temp-mail.com", workemailvalidator.CategoryDisposable},
+		{"no_at", "example.com", workemailvalidator.CategoryInvalid},
+		{"no_local", "@example.com", workemailvalidator.CategoryInvalid},
+		{"no_domain", "user@", workemailvalidator.CategoryInvalid},
+		{"tld_only", "user@com", workemailvalidator.CategoryInvalid},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := workemailvalidator.ClassifyEmail(testCase.email); got != testCase.expected {
+				t.Errorf("ClassifyEmail(%q) = %v, want %v", testCase.email, got, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestClassifyPrecedence ensures disposable wins over free, regardless of which level matched.
+func TestClassifyPrecedence(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithDisposableDomains("both.com", "parent.com"),
+		workemailvalidator.WithFreeDomains("both.com", "child.parent.com"),
+	)
+
+	tests := []struct {
+		domain   string
+		expected workemailvalidator.Category
+	}{
+		{"both.com", workemailvalidator.CategoryDisposable},
+		{"child.parent.com", workemailvalidator.CategoryDisposable},
+		{"x.child.parent.com", workemailvalidator.CategoryDisposable},
+	}
+
+	for _, testCase := range tests {
+		if got := validator.Classify(testCase.domain); got != testCase.expected {
+			t.Errorf("Classify(%q) = %v, want %v", testCase.domain, got, testCase.expected)
+		}
+
+		if !validator.IsDisposableDomain(testCase.domain) || validator.IsFreeDomain(testCase.domain) {
+			t.Errorf("the boolean helpers should follow the precedence of Classify for %q", testCase.domain)
+		}
+	}
+}
+
+// TestClassifyConsistency ensures Classify agrees with the boolean helpers.
+func TestClassifyConsistency(t *testing.T) {
+	t.Parallel()
+
+	domains := []string{"gmail.com", "temp-mail.com", "example.com", "outlook.com", "", "invalid", "sub.gmail.com"}
+
+	for _, domain := range domains {
+		category := workemailvalidator.Classify(domain)
+
+		if (category == workemailvalidator.CategoryBusiness) != workemailvalidator.IsBusinessDomain(domain) {
+			t.Errorf("Classify(%q) = %v disagrees with IsBusinessDomain", domain, category)
+		}
+
+		if category == workemailvalidator.CategoryDisposable && !workemailvalidator.IsDisposableDomain(domain) {
+			t.Errorf("Classify(%q) = %v disagrees with IsDisposableDomain", domain, category)
+		}
+
+		if category == workemailvalidator.CategoryFree && !workemailvalidator.IsFreeDomain(domain) {
+			t.Errorf("Classify(%q) = %v disagrees with IsFreeDomain", domain, category)
+		}
+	}
+}
+
+// TestCategoryString tests the names of the categories.
+func TestCategoryString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[workemailvalidator.Category]string{
+		workemailvalidator.CategoryInvalid:    "invalid",
+		workemailvalidator.CategoryDisposable: "disposable",
+		workemailvalidator.CategoryFree:       "free",
+		workemailvalidator.CategoryBusiness:   "business",
+		workemailvalidator.Category(-1):       "unknown",
+	}
+
+	for category, expected := range tests {
+		if got := category.String(); got != expected {
+			t.Errorf("Category(%d).String() = %q, want %q", int(category), got, expected)
+		}
+	}
+}
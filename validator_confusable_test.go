@@ -0,0 +1,136 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestConfusableWith tests mapping internationalized look-alike domains back to the domains they imitate.
+func TestConfusableWith(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"cyrillic_a", "gm\u0430il.com", "gmail.com"},
+		{"punycode_input", "xn--gmil-63d.com", "gmail.com"},
+		{"cyrillic_palochka", "gmai\u04cf.com", "gmail.com"},
+		{"cyrillic_er", "\u0440rotonmail.com", "protonmail.com"},
+		{"fullwidth", "\uff47mail.com", "gmail.com"},
+		{"zero_width_space", "gm\u200bail.com", "gmail.com"},
+		{"subdomain", "mail.y\u0430hoo.com", "yahoo.com"},
+		{"disposable", "mailin\u0430tor.com", "mailinator.com"},
+		{"ascii_free", "gmail.com", ""},
+		{"ascii_business", "example.com", ""},
+		{"legit_idn", "bücher.de", ""},
+		{"legit_idn_city", "münchen.de", ""},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := workemailvalidator.ConfusableWith(testCase.input)
+			if got != testCase.expected || ok != (testCase.expected != "") {
+				t.Errorf("ConfusableWith(%q) = %q, %v, want %q", testCase.input, got, ok, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestConfusableExplain tests how spoofs are reported by Explain and the boolean checks.
+func TestConfusableExplain(t *testing.T) {
+	t.Parallel()
+
+	result := workemailvalidator.Explain("mail.gm\u0430il.com")
+	expected := workemailvalidator.Result{
+		Domain:   "mail.xn--gmil-63d.com",
+		Category: workemailvalidator.CategoryFree,
+		List:     workemailvalidator.ListConfusable,
+		Match:    "gmail.com",
+		Depth:    1,
+		MX:       workemailvalidator.MXNotChecked,
+		Provider: workemailvalidator.ProviderUnknown,
+		Role:     false,
+		Sector:   workemailvalidator.SectorUnknown,
+	}
+
+	if result != expected {
+		t.Errorf("Explain() = %+v, want %+v", result, expected)
+	}
+
+	if got := workemailvalidator.ListConfusable.String(); got != "confusable" {
+		t.Errorf("ListConfusable.String() = %q, want %q", got, "confusable")
+	}
+
+	if !workemailvalidator.IsFreeDomain("gm\u0430il.com") || workemailvalidator.IsDisposableDomain("gm\u0430il.com") {
+		t.Error("a spoof of gmail.com should be reported as free")
+	}
+
+	if !workemailvalidator.IsDisposableDomain("mailin\u0430tor.com") || workemailvalidator.IsFreeDomain("mailin\u0430tor.com") {
+		t.Error("a spoof of mailinator.com should be reported as disposable")
+	}
+
+	if !workemailvalidator.IsDisposableOrFreeDomain("gm\u0430il.com") || workemailvalidator.IsBusinessDomain("gm\u0430il.com") {
+		t.Error("a spoof should not be a business domain")
+	}
+
+	err := workemailvalidator.ValidateWorkEmail("jane@gm\u0430il.com")
+	if !errors.Is(err, workemailvalidator.ErrConfusable) || !errors.Is(err, workemailvalidator.ErrFreeProvider) {
+		t.Errorf("ValidateWorkEmail(spoof) = %v, want ErrConfusable and ErrFreeProvider", err)
+	}
+
+	err = workemailvalidator.ValidateWorkEmail("jane@mailin\u0430tor.com")
+	if !errors.Is(err, workemailvalidator.ErrConfusable) || !errors.Is(err, workemailvalidator.ErrDisposable) {
+		t.Errorf("ValidateWorkEmail(disposable spoof) = %v, want ErrConfusable and ErrDisposable", err)
+	}
+
+	if errors.Is(err, workemailvalidator.ErrFreeProvider) {
+		t.Errorf("ValidateWorkEmail(disposable spoof) = %v, should not match ErrFreeProvider", err)
+	}
+
+	if suggestion, ok := workemailvalidator.Suggest("jane@gm\u0430il.com"); !ok || suggestion != "jane@gmail.com" {
+		t.Errorf("Suggest(spoof) = %q, %v, want jane@gmail.com", suggestion, ok)
+	}
+}
+
+// TestConfusableZeroValidator tests that a zero Validator checks internationalized domains without an index.
+func TestConfusableZeroValidator(t *testing.T) {
+	t.Parallel()
+
+	var validator workemailvalidator.Validator
+
+	if list := validator.Explain("xn--gmil-63d.com").List; list == workemailvalidator.ListConfusable {
+		t.Errorf("Explain(spoof).List = %v, want no spoof without lists", list)
+	}
+
+	if match, ok := validator.ConfusableWith("xn--gmil-63d.com"); ok {
+		t.Errorf("ConfusableWith(spoof) = %q, want no match without lists", match)
+	}
+}
+
+// TestConfusableOverrides tests that the allowlist and custom lists apply to spoof detection.
+func TestConfusableOverrides(t *testing.T) {
+	t.Parallel()
+
+	allowed := workemailvalidator.New(workemailvalidator.WithAllowlist("gm\u0430il.com"))
+	if !allowed.IsBusinessDomain("gm\u0430il.com") {
+		t.Error("the allowlist should override spoof detection")
+	}
+
+	custom := workemailvalidator.New(
+		workemailvalidator.WithFreeDomains("acme-mail.test"),
+		workemailvalidator.WithDisposableDomains(),
+	)
+	if match, ok := custom.ConfusableWith("\u0430cme-mail.test"); !ok || match != "acme-mail.test" {
+		t.Errorf("ConfusableWith() = %q, %v, want acme-mail.test from the custom free list", match, ok)
+	}
+
+	if _, ok := custom.ConfusableWith("gm\u0430il.com"); ok {
+		t.Error("gmail.com and its disposable look-alikes are not on the custom lists")
+	}
+}
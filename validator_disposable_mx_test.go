@@ -0,0 +1,147 @@
+package workemailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// newDisposableMXResolver returns a resolver with business domains served by various mail servers.
+func newDisposableMXResolver() *workemailvalidator.StaticResolver {
+	return &workemailvalidator.StaticResolver{
+		MX: map[string][]*net.MX{
+			"corp.com":          {{Host: "mx1.corp.com.", Pref: 10}},
+			"fresh-burner.com":  {{Host: "mail2.mailinator.com.", Pref: 10}},
+			"listed-host.com":   {{Host: "in.temp-mail.com.", Pref: 10}},
+			"custom-burner.com": {{Host: "mx.tempmail.example.", Pref: 10}},
+			"ip-burner.com":     {{Host: "mx.ip-burner.com.", Pref: 10}},
+			"nullmx.com":        {{Host: ".", Pref: 0}},
+		},
+		Hosts: map[string][]string{
+			"mx1.corp.com":        {"192.0.2.10"},
+			"mx.ip-burner.com":    {"198.51.100.7"},
+			"mx.tempmail.example": {"203.0.113.1"},
+		},
+		Errors: map[string]error{
+			"timeout.com": &net.DNSError{Err: errTimeout.Error(), Name: "timeout.com", IsTimeout: true},
+		},
+	}
+}
+
+// TestExplainContextDisposableMX tests that domains served by disposable mail infrastructure are reported as disposable.
+func TestExplainContextDisposableMX(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithResolver(newDisposableMXResolver()),
+		workemailvalidator.WithDisposableMXCheck(),
+	)
+
+	tests := []struct {
+		name     string
+		domain   string
+		category workemailvalidator.Category
+		list     workemailvalidator.List
+		match    string
+	}{
+		{"business", "corp.com", workemailvalidator.CategoryBusiness, workemailvalidator.ListNone, ""},
+		{"embedded_mx_host", "fresh-burner.com", workemailvalidator.CategoryDisposable, workemailvalidator.ListDisposableMX, "mailinator.com"},
+		{"disposable_domain_host", "listed-host.com", workemailvalidator.CategoryDisposable, workemailvalidator.ListDisposableMX, "temp-mail.com"},
+		{"null_mx_without_mx_check", "nullmx.com", workemailvalidator.CategoryBusiness, workemailvalidator.ListNone, ""},
+		{"lookup_error", "timeout.com", workemailvalidator.CategoryBusiness, workemailvalidator.ListNone, ""},
+		{"free_not_looked_up", "gmail.com", workemailvalidator.CategoryFree, workemailvalidator.ListFree, "gmail.com"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validator.ExplainContext(context.Background(), testCase.domain)
+			if result.Category != testCase.category || result.List != testCase.list || result.Match != testCase.match {
+				t.Errorf("ExplainContext(%q) = %+v, want category %v list %v match %q",
+					testCase.domain, result, testCase.category, testCase.list, testCase.match)
+			}
+		})
+	}
+}
+
+// TestWithDisposableMXHosts tests replacing the disposable mail infrastructure list with host names and address ranges.
+func TestWithDisposableMXHosts(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithResolver(newDisposableMXResolver()),
+		workemailvalidator.WithDisposableMXHosts("TempMail.example", "198.51.100.0/24", "192.0.2.99", ""),
+	)
+
+	tests := []struct {
+		name       string
+		domain     string
+		disposable bool
+	}{
+		{"custom_host", "custom-burner.com", true},
+		{"cidr", "ip-burner.com", true},
+		{"disposable_domain_host", "listed-host.com", true},
+		{"business", "corp.com", false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := validator.IsDisposableMX(context.Background(), testCase.domain)
+			if err != nil {
+				t.Fatalf("IsDisposableMX(%q) error = %v", testCase.domain, err)
+			}
+
+			if got != testCase.disposable {
+				t.Errorf("IsDisposableMX(%q) = %v, want %v", testCase.domain, got, testCase.disposable)
+			}
+		})
+	}
+
+	if _, err := validator.IsDisposableMX(context.Background(), "timeout.com"); err == nil {
+		t.Error("IsDisposableMX should report lookup errors")
+	}
+}
+
+// TestValidateWorkEmailContextDisposableMX tests the errors reported when both DNS checks are enabled.
+func TestValidateWorkEmailContextDisposableMX(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithResolver(newDisposableMXResolver()),
+		workemailvalidator.WithDisposableMXCheck(),
+		workemailvalidator.WithMXCheck(),
+	)
+
+	tests := []struct {
+		name  string
+		email string
+		want  error
+	}{
+		{"business", "jane@corp.com", nil},
+		{"disposable_mx", "jane@fresh-burner.com", workemailvalidator.ErrDisposableMX},
+		{"null_mx", "jane@nullmx.com", workemailvalidator.ErrNoMailServer},
+		{"disposable_domain", "jane@temp-mail.com", workemailvalidator.ErrDisposable},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.ValidateWorkEmailContext(context.Background(), testCase.email)
+			if !errors.Is(err, testCase.want) || (testCase.want == nil) != (err == nil) {
+				t.Errorf("ValidateWorkEmailContext(%q) = %v, want %v", testCase.email, err, testCase.want)
+			}
+		})
+	}
+
+	err := validator.ValidateWorkEmailContext(context.Background(), "jane@fresh-burner.com")
+	if !errors.Is(err, workemailvalidator.ErrDisposable) {
+		t.Errorf("ValidateWorkEmailContext(disposable MX) = %v, should also match ErrDisposable", err)
+	}
+}
@@ -0,0 +1,115 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestListEntrySyntax tests exact-only, subdomain-only and exempting entries in a custom disposable list.
+func TestListEntrySyntax(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithDisposableDomains(
+		"=exact.test",
+		"*.hosted.test",
+		"wide.test",
+		"!good.wide.test",
+		"!=self.wide.test",
+		"!*.zone.wide.test",
+		"both.test",
+		"!both.test",
+		"!*.co.uk",
+	))
+
+	tests := []testCase{
+		{"exact_domain", "exact.test", true},
+		{"exact_subdomain", "mail.exact.test", false},
+		{"subdomain_only_domain", "hosted.test", false},
+		{"subdomain_only_subdomain", "user.hosted.test", true},
+		{"subdomain_only_deep", "a.user.hosted.test", true},
+		{"plain_domain", "wide.test", true},
+		{"plain_subdomain", "x.wide.test", true},
+		{"exempt_domain", "good.wide.test", false},
+		{"exempt_subdomain", "mail.good.wide.test", false},
+		{"exempt_domain_only", "self.wide.test", false},
+		{"exempt_domain_only_subdomain", "mail.self.wide.test", true},
+		{"exempt_subdomains_only", "zone.wide.test", true},
+		{"exempt_subdomains_only_subdomain", "a.zone.wide.test", false},
+		{"exemption_beats_same_entry", "both.test", false},
+		{"uppercase_marker_domain", "EXACT.test", true},
+	}
+
+	runDomainTests(t, tests, validator.IsDisposableDomain)
+}
+
+// TestListEntryExplain tests that Explain reports the entry that decided, with its depth.
+func TestListEntryExplain(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithDisposableDomains("*.hosted.test", "wide.test", "!good.wide.test"),
+		workemailvalidator.WithFreeDomains("good.wide.test"),
+	)
+
+	result := validator.Explain("a.user.hosted.test")
+	if result.List != workemailvalidator.ListDisposable || result.Match != "hosted.test" || result.Depth != 2 {
+		t.Errorf("Explain(subdomain-only match) = %+v, want hosted.test at depth 2", result)
+	}
+
+	result = validator.Explain("mail.good.wide.test")
+	if result.List != workemailvalidator.ListFree || result.Match != "good.wide.test" || result.Depth != 1 {
+		t.Errorf("Explain(exempt domain) = %+v, want the free entry good.wide.test at depth 1", result)
+	}
+}
+
+// TestListEntryOverrides tests the entry syntax in the allowlist and the blocklist.
+func TestListEntryOverrides(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithBlocklist("*.customers.test", "!trusted.customers.test"),
+		workemailvalidator.WithAllowlist("=partner.test"),
+		workemailvalidator.WithDisposableDomains("partner.test"),
+	)
+
+	tests := []testCase{
+		{"blocked_subdomain", "a.customers.test", false},
+		{"blocklist_domain_not_covered", "customers.test", true},
+		{"blocklist_exemption", "trusted.customers.test", true},
+		{"allowlist_exact", "partner.test", true},
+		{"allowlist_exact_subdomain", "mail.partner.test", false},
+	}
+
+	runDomainTests(t, tests, validator.IsBusinessDomain)
+
+	if list := validator.Explain("a.customers.test").List; list != workemailvalidator.ListBlocklist {
+		t.Errorf("Explain(blocked subdomain).List = %v, want blocklist", list)
+	}
+}
+
+// TestReadDomainsEntrySyntax tests that the loader keeps entry markers and rejects markers without a domain.
+func TestReadDomainsEntrySyntax(t *testing.T) {
+	t.Parallel()
+
+	list := "=Exact.TEST\n*.hosted.test\n!Good.Wide.test\n!=self.test\n!*.zone.test\n*.münchen.de\n"
+
+	entries, err := workemailvalidator.ReadDomains(strings.NewReader(list))
+	if err != nil {
+		t.Fatalf("ReadDomains() error = %v", err)
+	}
+
+	expected := []string{"=exact.test", "*.hosted.test", "!good.wide.test", "!=self.test", "!*.zone.test", "*.xn--mnchen-3ya.de"}
+	if !slices.Equal(entries, expected) {
+		t.Errorf("ReadDomains() = %v, want %v", entries, expected)
+	}
+
+	for _, line := range []string{"!", "=", "*.", "**.example.com", "=*.example.com", "!!example.com"} {
+		if _, err := workemailvalidator.ReadDomains(strings.NewReader(line)); !errors.Is(err, workemailvalidator.ErrMalformedEntry) {
+			t.Errorf("ReadDomains(%q) error = %v, want ErrMalformedEntry", line, err)
+		}
+	}
+}
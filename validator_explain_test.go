@@ -0,0 +1,117 @@
+package workemailvalidator_test
+
+import (
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestExplain tests that Explain reports the matching entry, its depth and its list.
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		domain   string
+		expected workemailvalidator.Result
+	}{
+		{
+			"exact_disposable", "temp-mail.org",
+			workemailvalidator.Result{
+				Domain: "temp-mail.org", Category: workemailvalidator.CategoryDisposable,
+				List: workemailvalidator.ListDisposable, Match: "temp-mail.org", Depth: 0,
+			},
+		},
+		{
+			"parent_disposable", "mail.foo.temp-mail.org",
+			workemailvalidator.Result{
+				Domain: "mail.foo.temp-mail.org", Category: workemailvalidator.CategoryDisposable,
+				List: workemailvalidator.ListDisposable, Match: "temp-mail.org", Depth: 2,
+			},
+		},
+		{
+			"parent_free", " Mail.GMAIL.com ",
+			workemailvalidator.Result{
+				Domain: "mail.gmail.com", Category: workemailvalidator.CategoryFree,
+				List: workemailvalidator.ListFree, Match: "gmail.com", Depth: 1,
+			},
+		},
+		{
+			"business_punycode", "münchen.de",
+			workemailvalidator.Result{
+				Domain: "xn--mnchen-3ya.de", Category: workemailvalidator.CategoryBusiness,
+				List: workemailvalidator.ListNone, Match: "", Depth: 0, Sector: workemailvalidator.SectorCorporate,
+			},
+		},
+		{
+			"invalid", "domain",
+			workemailvalidator.Result{
+				Domain: "domain", Category: workemailvalidator.CategoryInvalid,
+				List: workemailvalidator.ListNone, Match: "", Depth: 0,
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := workemailvalidator.Explain(testCase.domain); got != testCase.expected {
+				t.Errorf("Explain(%q) = %+v, want %+v", testCase.domain, got, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestExplainPrecedence ensures a disposable parent wins over a more specific free entry.
+func TestExplainPrecedence(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithDisposableDomains("parent.com"),
+		workemailvalidator.WithFreeDomains("child.parent.com"),
+	)
+
+	result := validator.Explain("x.child.parent.com")
+	if result.List != workemailvalidator.ListDisposable || result.Match != "parent.com" || result.Depth != 2 {
+		t.Errorf("Explain(%q) = %+v, want disposable match on parent.com at depth 2", "x.child.parent.com", result)
+	}
+}
+
+// TestExplainEmail tests explanations for the domain part of an email address.
+func TestExplainEmail(t *testing.T) {
+	t.Parallel()
+
+	result := workemailvalidator.ExplainEmail("user@x.temp-mail.org")
+	if result.Match != "temp-mail.org" || result.Depth != 1 || result.Category != workemailvalidator.CategoryDisposable {
+		t.Errorf("ExplainEmail() = %+v, want disposable match on temp-mail.org at depth 1", result)
+	}
+
+	result = workemailvalidator.ExplainEmail("user@")
+	if result.Category != workemailvalidator.CategoryInvalid || result.Domain != "" {
+		t.Errorf("ExplainEmail(%q) = %+v, want invalid result", "user@", result)
+	}
+}
+
+// TestListString tests the names of the lists.
+func TestListString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[workemailvalidator.List]string{
+		workemailvalidator.ListNone:         "none",
+		workemailvalidator.ListDisposable:   "disposable",
+		workemailvalidator.ListFree:         "free",
+		workemailvalidator.ListAllowlist:    "allowlist",
+		workemailvalidator.ListBlocklist:    "blocklist",
+		workemailvalidator.ListDisposableMX: "disposable_mx",
+		workemailvalidator.ListRelay:        "relay",
+		workemailvalidator.ListFreeTypo:     "free_typo",
+		workemailvalidator.List(-1):         "unknown",
+	}
+
+	for list, expected := range tests {
+		if got := list.String(); got != expected {
+			t.Errorf("List(%d).String() = %q, want %q", int(list), got, expected)
+		}
+	}
+}
@@ -200,9 +200,7 @@ func FuzzIsDisposableOrFreeDomain(f *testing.F) {
 	})
 }
 
-// FuzzIsWorkEmail tests IsWorkEmail with random inputs
-//
-//nolint:cyclop
+// FuzzIsWorkEmail tests IsWorkEmail with random inputs.
 func FuzzIsWorkEmail(f *testing.F) {
 	seeds := []string{
 		"user@example.com",
@@ -221,6 +219,11 @@ func FuzzIsWorkEmail(f *testing.F) {
 		"user@münchen.de",
 		"user+tag@example.com",
 		"first.last@example.com",
+		`"a@b"@example.com`,
+		"Jane <jane@example.com>",
+		"jane(comment)@example.com",
+		`"unterminated@example.com`,
+		"(unterminated@example.com",
 	}
 
 	for _, seed := range seeds {
@@ -242,31 +245,18 @@ func FuzzIsWorkEmail(f *testing.F) {
 			t.Errorf("IsWorkEmail not deterministic for %q", email)
 		}
 
-		// If email is valid work email, extract domain and verify consistency
-		if result && strings.Contains(email, "@") {
-			atIndex := strings.LastIndexByte(email, '@')
-			if atIndex > 0 && atIndex < len(email)-1 {
-				domain := email[atIndex+1:]
-
-				business := workemailvalidator.IsBusinessDomain(domain)
-				if !business {
-					t.Errorf("IsWorkEmail(%q)=true but domain %q is not business", email, domain)
-				}
+		// IsWorkEmail must agree with the domain extracted by ParseAddress
+		address, err := workemailvalidator.ParseAddress(email)
+		if err != nil {
+			if result {
+				t.Errorf("IsWorkEmail(%q)=true but ParseAddress failed: %v", email, err)
 			}
+
+			return
 		}
 
-		// If email is not work email due to invalid format, check edge cases
-		if !result && strings.Contains(email, "@") {
-			atIndex := strings.LastIndexByte(email, '@')
-			if atIndex > 0 && atIndex < len(email)-1 {
-				// Valid format, so must be non-business domain
-				domain := email[atIndex+1:]
-
-				business := workemailvalidator.IsBusinessDomain(domain)
-				if business {
-					t.Errorf("IsWorkEmail(%q)=false but domain %q is business", email, domain)
-				}
-			}
+		if business := workemailvalidator.IsBusinessDomain(address.Domain); business != result {
+			t.Errorf("IsWorkEmail(%q)=%v but IsBusinessDomain(%q)=%v", email, result, address.Domain, business)
 		}
 	})
 }
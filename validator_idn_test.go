@@ -1,6 +1,7 @@
 package workemailvalidator_test
 
 import (
+	"errors"
 	"testing"
 
 	workemailvalidator "github.com/rixlhq/work-email-validator"
@@ -221,3 +222,116 @@ func TestIDNInvalidDomains(t *testing.T) {
 		})
 	}
 }
+
+// TestStrictIDN tests that strict validators treat IDNA failures as invalid.
+func TestStrictIDN(t *testing.T) {
+	t.Parallel()
+
+	lenient := workemailvalidator.New(workemailvalidator.WithIDNAProfile(workemailvalidator.IDNALookup))
+	strict := workemailvalidator.New(
+		workemailvalidator.WithIDNAProfile(workemailvalidator.IDNALookup),
+		workemailvalidator.WithStrictIDN(),
+	)
+
+	tests := []struct {
+		name     string
+		domain   string
+		lenient  workemailvalidator.Category
+		strict   workemailvalidator.Category
+		business bool
+	}{
+		{"valid_idn", "münchen.de", workemailvalidator.CategoryBusiness, workemailvalidator.CategoryBusiness, true},
+		{"bad_punycode", "xn--55555555.com", workemailvalidator.CategoryBusiness, workemailvalidator.CategoryInvalid, false},
+		{"bidi_mark", "test‎.com", workemailvalidator.CategoryBusiness, workemailvalidator.CategoryInvalid, false},
+		{"underscore", "my_company.com", workemailvalidator.CategoryBusiness, workemailvalidator.CategoryInvalid, false},
+		{"free", "GMAIL.com", workemailvalidator.CategoryFree, workemailvalidator.CategoryFree, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := lenient.Classify(testCase.domain); got != testCase.lenient {
+				t.Errorf("lenient Classify(%q) = %v, want %v", testCase.domain, got, testCase.lenient)
+			}
+
+			if got := strict.Classify(testCase.domain); got != testCase.strict {
+				t.Errorf("strict Classify(%q) = %v, want %v", testCase.domain, got, testCase.strict)
+			}
+
+			if got := strict.IsBusinessDomain(testCase.domain); got != testCase.business {
+				t.Errorf("strict IsBusinessDomain(%q) = %v, want %v", testCase.domain, got, testCase.business)
+			}
+		})
+	}
+
+	if strict.IsDisposableDomain("xn--zz.temp-mail.org") || strict.IsFreeDomain("xn--zz.gmail.com") ||
+		strict.IsDisposableOrFreeDomain("xn--zz.gmail.com") || strict.Sources("xn--zz.temp-mail.org") != nil {
+		t.Error("strict validator should not match domains that fail conversion")
+	}
+
+	if result := strict.Explain(" XN--ZZ.com "); result.Category != workemailvalidator.CategoryInvalid || result.Domain != "xn--zz.com" {
+		t.Errorf("strict Explain() = %+v, want invalid result for the trimmed input", result)
+	}
+}
+
+// TestIDNAProfiles tests that the profile changes how domains are converted.
+func TestIDNAProfiles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		profile  workemailvalidator.IDNAProfile
+		name     string
+		domain   string
+		expected string
+		fails    bool
+	}{
+		{workemailvalidator.IDNAPunycode, "punycode", "MÜNCHEN.de", "xn--mnchen-3ya.de", false},
+		{workemailvalidator.IDNAPunycode, "punycode", "ab--c.com", "ab--c.com", false},
+		{workemailvalidator.IDNALookup, "lookup", "MÜNCHEN.de", "xn--mnchen-3ya.de", false},
+		{workemailvalidator.IDNALookup, "lookup", "ab--c.com", "", true},
+		{workemailvalidator.IDNARegistration, "registration", "münchen.de", "xn--mnchen-3ya.de", false},
+		{workemailvalidator.IDNARegistration, "registration", "-abc.com", "", true},
+		{workemailvalidator.IDNADisplay, "display", "münchen.de", "xn--mnchen-3ya.de", false},
+		{workemailvalidator.IDNAProfile(-1), "unknown", "münchen.de", "xn--mnchen-3ya.de", false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name+"_"+testCase.domain, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.profile.String(); got != testCase.name {
+				t.Errorf("String() = %q, want %q", got, testCase.name)
+			}
+
+			validator := workemailvalidator.New(workemailvalidator.WithIDNAProfile(testCase.profile))
+
+			normalized, err := validator.NormalizeDomain(testCase.domain)
+			if testCase.fails {
+				if !errors.Is(err, workemailvalidator.ErrIDNConversion) {
+					t.Errorf("NormalizeDomain(%q) error = %v, want ErrIDNConversion", testCase.domain, err)
+				}
+
+				return
+			}
+
+			if err != nil || normalized != testCase.expected {
+				t.Errorf("NormalizeDomain(%q) = %q, %v, want %q", testCase.domain, normalized, err, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestNormalizeDomain tests the package-level conversion helper.
+func TestNormalizeDomain(t *testing.T) {
+	t.Parallel()
+
+	normalized, err := workemailvalidator.NormalizeDomain("  Москва.РФ ")
+	if err != nil || normalized != "xn--80adxhks.xn--p1ai" {
+		t.Errorf("NormalizeDomain() = %q, %v, want xn--80adxhks.xn--p1ai", normalized, err)
+	}
+
+	if _, err := workemailvalidator.NormalizeDomain("xn--zz.com"); !errors.Is(err, workemailvalidator.ErrIDNConversion) {
+		t.Errorf("NormalizeDomain() error = %v, want ErrIDNConversion", err)
+	}
+}
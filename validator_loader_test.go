@@ -0,0 +1,156 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+const validList = `# Custom disposable list
+# Last updated: today
+
+Throwaway.TEST
+  spam.io
+münchen.de
+`
+
+// TestReadDomains tests parsing a well-formed list from a reader.
+func TestReadDomains(t *testing.T) {
+	t.Parallel()
+
+	domains, err := workemailvalidator.ReadDomains(strings.NewReader(validList))
+	if err != nil {
+		t.Fatalf("ReadDomains() error = %v", err)
+	}
+
+	expected := []string{"throwaway.test", "spam.io", "xn--mnchen-3ya.de"}
+	if !slices.Equal(domains, expected) {
+		t.Errorf("ReadDomains() = %v, want %v", domains, expected)
+	}
+
+	validator := workemailvalidator.New(workemailvalidator.WithDisposableDomains(domains...))
+	if !validator.IsDisposableDomain("x.spam.io") {
+		t.Error("loaded list should be usable with WithDisposableDomains")
+	}
+}
+
+// TestReadDomainsMalformed tests that every malformed line is reported with its line number.
+func TestReadDomainsMalformed(t *testing.T) {
+	t.Parallel()
+
+	list := "good.com\nnot a domain\n# comment\nnodot\ngood.org\n"
+
+	domains, err := workemailvalidator.ReadDomains(strings.NewReader(list))
+	if err == nil {
+		t.Fatalf("ReadDomains() = %v, want error", domains)
+	}
+
+	if !errors.Is(err, workemailvalidator.ErrMalformedEntry) {
+		t.Errorf("ReadDomains() error = %v, want ErrMalformedEntry", err)
+	}
+
+	var parseErr *workemailvalidator.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Text != "not a domain" {
+		t.Errorf("first ParseError = %+v, want line 2", parseErr)
+	}
+
+	for _, want := range []string{`line 2: "not a domain"`, `line 4: "nodot"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ReadDomains() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
+
+// TestReadDomainsFile tests reading a list from disk.
+func TestReadDomainsFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	name := filepath.Join(dir, "list.txt")
+
+	if err := os.WriteFile(name, []byte("a.com\nbad\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := workemailvalidator.ReadDomainsFile(name)
+	if err == nil || !strings.Contains(err.Error(), name+":2:") {
+		t.Errorf("ReadDomainsFile() error = %v, want it to name the file and line", err)
+	}
+
+	_, err = workemailvalidator.ReadDomainsFile(filepath.Join(dir, "missing.txt"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadDomainsFile() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+// TestReadDomainsFS tests reading every matching list from a file system.
+func TestReadDomainsFS(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"lists/b.txt":    {Data: []byte("b.com\n")},
+		"lists/a.txt":    {Data: []byte("a.com\n")},
+		"lists/skip.md":  {Data: []byte("not a list\n")},
+		"broken/bad.txt": {Data: []byte("ok.com\n\nbad entry\n")},
+	}
+
+	domains, err := workemailvalidator.ReadDomainsFS(fsys, "lists/*.txt")
+	if err != nil {
+		t.Fatalf("ReadDomainsFS() error = %v", err)
+	}
+
+	if expected := []string{"a.com", "b.com"}; !slices.Equal(domains, expected) {
+		t.Errorf("ReadDomainsFS() = %v, want %v", domains, expected)
+	}
+
+	_, err = workemailvalidator.ReadDomainsFS(fsys, "broken/*.txt")
+
+	var parseErr *workemailvalidator.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Name != "broken/bad.txt" || parseErr.Line != 3 {
+		t.Errorf("ReadDomainsFS() error = %v, want ParseError for broken/bad.txt line 3", err)
+	}
+
+	_, err = workemailvalidator.ReadDomainsFS(fsys, "missing/*.txt")
+	if !errors.Is(err, workemailvalidator.ErrNoListFiles) {
+		t.Errorf("ReadDomainsFS() error = %v, want ErrNoListFiles", err)
+	}
+
+	_, err = workemailvalidator.ReadDomainsFS(fsys, "[")
+	if err == nil {
+		t.Error("ReadDomainsFS() with bad pattern should fail")
+	}
+}
+
+// TestReadDomainsDir tests reading every .txt list in a directory.
+func TestReadDomainsDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for name, data := range map[string]string{"one.txt": "one.com\n", "two.txt": "two.com\n", "notes.md": "x\n"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	domains, err := workemailvalidator.ReadDomainsDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDomainsDir() error = %v", err)
+	}
+
+	if expected := []string{"one.com", "two.com"}; !slices.Equal(domains, expected) {
+		t.Errorf("ReadDomainsDir() = %v, want %v", domains, expected)
+	}
+
+	_, err = workemailvalidator.ReadDomainsDir(t.TempDir())
+	if !errors.Is(err, workemailvalidator.ErrNoListFiles) {
+		t.Errorf("ReadDomainsDir() on empty directory error = %v, want ErrNoListFiles", err)
+	}
+}
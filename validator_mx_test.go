@@ -0,0 +1,216 @@
+package workemailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+var errTimeout = errors.New("i/o timeout")
+
+// newTestResolver returns a resolver with one domain per MX scenario.
+func newTestResolver() *workemailvalidator.StaticResolver {
+	return &workemailvalidator.StaticResolver{
+		MX: map[string][]*net.MX{
+			"corp.com": {
+				{Host: "backup.corp.com.", Pref: 20},
+				{Host: "MX1.corp.com.", Pref: 10},
+			},
+			"nullmx.com":        {{Host: ".", Pref: 0}},
+			"nodata.com":        {},
+			"xn--mnchen-3ya.de": {{Host: "mail.example.net.", Pref: 10}},
+		},
+		Hosts: map[string][]string{
+			"nodata.com":   {"192.0.2.1"},
+			"implicit.com": {"192.0.2.2", "2001:db8::2"},
+		},
+		Errors: map[string]error{
+			"timeout.com": &net.DNSError{Err: errTimeout.Error(), Name: "timeout.com", IsTimeout: true},
+		},
+	}
+}
+
+// TestLookupMX tests each outcome of the MX lookup.
+func TestLookupMX(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithResolver(newTestResolver()))
+
+	tests := []struct {
+		name   string
+		domain string
+		status workemailvalidator.MXStatus
+		hosts  []string
+	}{
+		{"found_sorted", "CORP.com", workemailvalidator.MXFound, []string{"mx1.corp.com", "backup.corp.com"}},
+		{"found_idn", "münchen.de", workemailvalidator.MXFound, []string{"mail.example.net"}},
+		{"null_mx", "nullmx.com", workemailvalidator.MXNull, nil},
+		{"no_mx_records", "nodata.com", workemailvalidator.MXImplicit, []string{"nodata.com"}},
+		{"implicit_mx", "implicit.com", workemailvalidator.MXImplicit, []string{"implicit.com"}},
+		{"nothing", "missing.com", workemailvalidator.MXNone, nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := validator.LookupMX(context.Background(), testCase.domain)
+			if err != nil {
+				t.Fatalf("LookupMX(%q) error = %v", testCase.domain, err)
+			}
+
+			if result.Status != testCase.status || !slices.Equal(result.Hosts, testCase.hosts) {
+				t.Errorf("LookupMX(%q) = %+v, want status %v hosts %v", testCase.domain, result, testCase.status, testCase.hosts)
+			}
+		})
+	}
+}
+
+// TestLookupMXErrors tests that lookup and conversion failures are reported.
+func TestLookupMXErrors(t *testing.T) {
+	t.Parallel()
+
+	resolver := newTestResolver()
+	validator := workemailvalidator.New(workemailvalidator.WithResolver(resolver))
+
+	result, err := validator.LookupMX(context.Background(), "timeout.com")
+	if err == nil || result.Status != workemailvalidator.MXError {
+		t.Errorf("LookupMX(timeout.com) = %+v, %v, want MXError", result, err)
+	}
+
+	resolver.Errors = map[string]error{}
+	validator = workemailvalidator.New(workemailvalidator.WithResolver(&hostFailResolver{resolver}))
+
+	result, err = validator.LookupMX(context.Background(), "nothing.com")
+	if !errors.Is(err, errTimeout) || result.Status != workemailvalidator.MXError {
+		t.Errorf("LookupMX(nothing.com) = %+v, %v, want MXError from the host lookup", result, err)
+	}
+
+	result, err = validator.LookupMX(context.Background(), "xn--zz.com")
+	if !errors.Is(err, workemailvalidator.ErrIDNConversion) || result.Status != workemailvalidator.MXError {
+		t.Errorf("LookupMX(xn--zz.com) = %+v, %v, want ErrIDNConversion", result, err)
+	}
+}
+
+// hostFailResolver fails every host lookup.
+type hostFailResolver struct {
+	*workemailvalidator.StaticResolver
+}
+
+func (r *hostFailResolver) LookupHost(context.Context, string) ([]string, error) {
+	return nil, errTimeout
+}
+
+// TestExplainContextMXCheck tests that MX results are folded into the classification.
+func TestExplainContextMXCheck(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithResolver(newTestResolver()),
+		workemailvalidator.WithMXCheck(),
+	)
+
+	tests := []struct {
+		name     string
+		domain   string
+		category workemailvalidator.Category
+		mx       workemailvalidator.MXStatus
+	}{
+		{"accepts_mail", "corp.com", workemailvalidator.CategoryBusiness, workemailvalidator.MXFound},
+		{"implicit", "implicit.com", workemailvalidator.CategoryBusiness, workemailvalidator.MXImplicit},
+		{"null_mx", "nullmx.com", workemailvalidator.CategoryNoMail, workemailvalidator.MXNull},
+		{"no_records", "missing.com", workemailvalidator.CategoryNoMail, workemailvalidator.MXNone},
+		{"lookup_error", "timeout.com", workemailvalidator.CategoryBusiness, workemailvalidator.MXError},
+		{"free_not_checked", "gmail.com", workemailvalidator.CategoryFree, workemailvalidator.MXNotChecked},
+		{"invalid_not_checked", "corp", workemailvalidator.CategoryInvalid, workemailvalidator.MXNotChecked},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validator.ExplainContext(context.Background(), testCase.domain)
+			if result.Category != testCase.category || result.MX != testCase.mx {
+				t.Errorf("ExplainContext(%q) = %+v, want category %v and MX %v", testCase.domain, result, testCase.category, testCase.mx)
+			}
+
+			emailResult := validator.ExplainEmailContext(context.Background(), "user@"+testCase.domain)
+			if emailResult != result {
+				t.Errorf("ExplainEmailContext() = %+v, want %+v", emailResult, result)
+			}
+		})
+	}
+
+	if result := validator.ExplainEmailContext(context.Background(), "not-an-email"); result.Category != workemailvalidator.CategoryInvalid {
+		t.Errorf("ExplainEmailContext(not-an-email) = %+v, want invalid", result)
+	}
+
+	unchecked := workemailvalidator.New(workemailvalidator.WithResolver(newTestResolver()))
+	if result := unchecked.ExplainContext(context.Background(), "nullmx.com"); result.MX != workemailvalidator.MXNotChecked {
+		t.Errorf("ExplainContext() without WithMXCheck = %+v, want no MX lookup", result)
+	}
+}
+
+// TestValidateWorkEmailContext tests that domains without mail servers fail validation.
+func TestValidateWorkEmailContext(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithResolver(newTestResolver()),
+		workemailvalidator.WithMXCheck(),
+	)
+	ctx := context.Background()
+
+	if err := validator.ValidateWorkEmailContext(ctx, "jane@corp.com"); err != nil {
+		t.Errorf("ValidateWorkEmailContext(jane@corp.com) = %v, want nil", err)
+	}
+
+	if err := validator.ValidateWorkEmailContext(ctx, "jane@nullmx.com"); !errors.Is(err, workemailvalidator.ErrNoMailServer) {
+		t.Errorf("ValidateWorkEmailContext(jane@nullmx.com) = %v, want ErrNoMailServer", err)
+	}
+
+	if err := validator.ValidateWorkEmailContext(ctx, "jane@gmail.com"); !errors.Is(err, workemailvalidator.ErrFreeProvider) {
+		t.Errorf("ValidateWorkEmailContext(jane@gmail.com) = %v, want ErrFreeProvider", err)
+	}
+
+	if err := validator.ValidateWorkEmailContext(ctx, "jane@timeout.com"); err != nil {
+		t.Errorf("ValidateWorkEmailContext(jane@timeout.com) = %v, want nil for unknown MX status", err)
+	}
+}
+
+// TestMXStatus tests the status names and whether they accept mail.
+func TestMXStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status  workemailvalidator.MXStatus
+		name    string
+		accepts bool
+	}{
+		{workemailvalidator.MXNotChecked, "not_checked", false},
+		{workemailvalidator.MXFound, "found", true},
+		{workemailvalidator.MXImplicit, "implicit", true},
+		{workemailvalidator.MXNull, "null", false},
+		{workemailvalidator.MXNone, "none", false},
+		{workemailvalidator.MXError, "error", false},
+		{workemailvalidator.MXStatus(-1), "unknown", false},
+	}
+
+	for _, testCase := range tests {
+		if got := testCase.status.String(); got != testCase.name {
+			t.Errorf("MXStatus(%d).String() = %q, want %q", int(testCase.status), got, testCase.name)
+		}
+
+		if got := testCase.status.AcceptsMail(); got != testCase.accepts {
+			t.Errorf("%v.AcceptsMail() = %v, want %v", testCase.status, got, testCase.accepts)
+		}
+	}
+
+	if got := workemailvalidator.CategoryNoMail.String(); got != "no_mail" {
+		t.Errorf("CategoryNoMail.String() = %q, want %q", got, "no_mail")
+	}
+}
@@ -0,0 +1,109 @@
+package workemailvalidator_test
+
+import (
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestNewDefaults ensures a Validator built without options matches the package-level functions.
+func TestNewDefaults(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New()
+
+	domains := []string{"gmail.com", "temp-mail.com", "example.com", "sub.temp-mail.com", "", "invalid"}
+
+	for _, domain := range domains {
+		t.Run(domain, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := validator.IsDisposableDomain(domain), workemailvalidator.IsDisposableDomain(domain); got != want {
+				t.Errorf("IsDisposableDomain(%q) = %v, want %v", domain, got, want)
+			}
+
+			if got, want := validator.IsFreeDomain(domain), workemailvalidator.IsFreeDomain(domain); got != want {
+				t.Errorf("IsFreeDomain(%q) = %v, want %v", domain, got, want)
+			}
+
+			if got, want := validator.IsBusinessDomain(domain), workemailvalidator.IsBusinessDomain(domain); got != want {
+				t.Errorf("IsBusinessDomain(%q) = %v, want %v", domain, got, want)
+			}
+		})
+	}
+}
+
+// TestWithDisposableDomains tests replacing the disposable list on a single instance.
+func TestWithDisposableDomains(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithDisposableDomains("Throwaway.TEST", "  spam.io  ", ""))
+
+	tests := []testCase{
+		{"custom_entry", "throwaway.test", true},
+		{"custom_entry_case", "THROWAWAY.test", true},
+		{"custom_entry_trimmed", "spam.io", true},
+		{"custom_subdomain", "x.throwaway.test", true},
+		{"embedded_entry_replaced", "temp-mail.com", false},
+		{"empty", "", false},
+	}
+
+	runDomainTests(t, tests, validator.IsDisposableDomain)
+}
+
+// TestWithFreeDomains tests replacing the free list on a single instance.
+func TestWithFreeDomains(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithFreeDomains("münchen.de"))
+
+	tests := []testCase{
+		{"custom_idn_entry", "münchen.de", true},
+		{"custom_idn_punycode", "xn--mnchen-3ya.de", true},
+		{"embedded_entry_replaced", "gmail.com", false},
+	}
+
+	runDomainTests(t, tests, validator.IsFreeDomain)
+}
+
+// TestValidatorsSideBySide ensures two validators with different policies do not affect each other.
+func TestValidatorsSideBySide(t *testing.T) {
+	t.Parallel()
+
+	strict := workemailvalidator.New(workemailvalidator.WithDisposableDomains("partner.io"))
+	lenient := workemailvalidator.New(workemailvalidator.WithFreeDomains(), workemailvalidator.WithDisposableDomains())
+
+	tests := []struct {
+		name    string
+		email   string
+		strict  bool
+		lenient bool
+	}{
+		{"partner", "user@partner.io", false, true},
+		{"gmail", "user@gmail.com", false, true},
+		{"business", "user@example.com", true, true},
+		{"invalid", "user@", false, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := strict.IsWorkEmail(testCase.email); got != testCase.strict {
+				t.Errorf("strict.IsWorkEmail(%q) = %v, want %v", testCase.email, got, testCase.strict)
+			}
+
+			if got := lenient.IsWorkEmail(testCase.email); got != testCase.lenient {
+				t.Errorf("lenient.IsWorkEmail(%q) = %v, want %v", testCase.email, got, testCase.lenient)
+			}
+		})
+	}
+
+	if !workemailvalidator.IsDisposableDomain("temp-mail.com") {
+		t.Error("package-level list should be unaffected by instance options")
+	}
+
+	if !workemailvalidator.IsDisposableOrFreeDomain("gmail.com") || strict.IsDisposableOrFreeDomain("partner.com") {
+		t.Error("IsDisposableOrFreeDomain should follow each instance's lists")
+	}
+}
@@ -0,0 +1,122 @@
+package workemailvalidator_test
+
+import (
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// newOverridesValidator builds a validator with overlapping allowlist and blocklist entries.
+func newOverridesValidator() *workemailvalidator.Validator {
+	return workemailvalidator.New(
+		workemailvalidator.WithAllowlist("temp-mail.org", "ok.blocked.io", "both.io"),
+		workemailvalidator.WithBlocklist("blocked.io", "GMAIL.com", "both.io"),
+	)
+}
+
+// TestOverridesExplain tests that overrides take precedence and are reported in the explanation.
+func TestOverridesExplain(t *testing.T) {
+	t.Parallel()
+
+	validator := newOverridesValidator()
+
+	tests := []struct {
+		name     string
+		domain   string
+		category workemailvalidator.Category
+		list     workemailvalidator.List
+		match    string
+		depth    int
+	}{
+		{
+			"allowlisted_disposable", "temp-mail.org",
+			workemailvalidator.CategoryBusiness, workemailvalidator.ListAllowlist, "temp-mail.org", 0,
+		},
+		{
+			"allowlisted_subdomain", "mx.temp-mail.org",
+			workemailvalidator.CategoryBusiness, workemailvalidator.ListAllowlist, "temp-mail.org", 1,
+		},
+		{
+			"blocklisted_business", "blocked.io",
+			workemailvalidator.CategoryDisposable, workemailvalidator.ListBlocklist, "blocked.io", 0,
+		},
+		{
+			"blocklisted_free", "mail.gmail.com",
+			workemailvalidator.CategoryDisposable, workemailvalidator.ListBlocklist, "gmail.com", 1,
+		},
+		{
+			"more_specific_allow", "a.ok.blocked.io",
+			workemailvalidator.CategoryBusiness, workemailvalidator.ListAllowlist, "ok.blocked.io", 1,
+		},
+		{
+			"both_lists", "both.io",
+			workemailvalidator.CategoryDisposable, workemailvalidator.ListBlocklist, "both.io", 0,
+		},
+		{
+			"unaffected_free", "outlook.com",
+			workemailvalidator.CategoryFree, workemailvalidator.ListFree, "outlook.com", 0,
+		},
+		{
+			"invalid_stays_invalid", "blocked",
+			workemailvalidator.CategoryInvalid, workemailvalidator.ListNone, "", 0,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validator.Explain(testCase.domain)
+			if result.Category != testCase.category || result.List != testCase.list ||
+				result.Match != testCase.match || result.Depth != testCase.depth {
+				t.Errorf("Explain(%q) = %+v, want category=%v list=%v match=%q depth=%d", testCase.domain, result,
+					testCase.category, testCase.list, testCase.match, testCase.depth)
+			}
+		})
+	}
+}
+
+// TestOverridesBooleanHelpers tests that the boolean helpers honor the overrides.
+func TestOverridesBooleanHelpers(t *testing.T) {
+	t.Parallel()
+
+	validator := newOverridesValidator()
+
+	tests := []struct {
+		name             string
+		domain           string
+		disposable       bool
+		free             bool
+		disposableOrFree bool
+		business         bool
+	}{
+		{"allowlisted_disposable", "temp-mail.org", false, false, false, true},
+		{"blocklisted_free", "gmail.com", true, false, true, false},
+		{"blocklisted_business", "x.blocked.io", true, false, true, false},
+		{"allowlisted_subdomain_of_blocked", "ok.blocked.io", false, false, false, true},
+		{"no_override_free", "outlook.com", false, true, true, false},
+		{"no_override_business", "example.com", false, false, false, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := validator.IsDisposableDomain(testCase.domain); got != testCase.disposable {
+				t.Errorf("IsDisposableDomain(%q) = %v, want %v", testCase.domain, got, testCase.disposable)
+			}
+
+			if got := validator.IsFreeDomain(testCase.domain); got != testCase.free {
+				t.Errorf("IsFreeDomain(%q) = %v, want %v", testCase.domain, got, testCase.free)
+			}
+
+			if got := validator.IsDisposableOrFreeDomain(testCase.domain); got != testCase.disposableOrFree {
+				t.Errorf("IsDisposableOrFreeDomain(%q) = %v, want %v", testCase.domain, got, testCase.disposableOrFree)
+			}
+
+			if got := validator.IsBusinessDomain(testCase.domain); got != testCase.business {
+				t.Errorf("IsBusinessDomain(%q) = %v, want %v", testCase.domain, got, testCase.business)
+			}
+		})
+	}
+}
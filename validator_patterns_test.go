@@ -0,0 +1,224 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestExplainPattern tests that the embedded domain rules flag unlisted throwaway names and report the pattern.
+func TestExplainPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		domain   string
+		list     workemailvalidator.List
+		match    string
+		depth    int
+		category workemailvalidator.Category
+	}{
+		{"numbered_glob", "tempmail-42.net", workemailvalidator.ListPattern, "tempmail-[0-9]*.*", 0, workemailvalidator.CategoryDisposable},
+		{"subdomain", "mx.tempmail-9000.io", workemailvalidator.ListPattern, "tempmail-[0-9]*.*", 1, workemailvalidator.CategoryDisposable},
+		{"throwaway_regex", "burnermail24.com", workemailvalidator.ListPattern, `(temp|tmp|trash|throwaway|burner|fake|spam)-?e?mail[0-9-]*\.[a-z.]+`, 0, workemailvalidator.CategoryDisposable},
+		{"minutes_regex", "15-minute-mail.co.uk", workemailvalidator.ListPattern, `[0-9]+-?minutes?-?e?mail[0-9-]*\.[a-z.]+`, 0, workemailvalidator.CategoryDisposable},
+		{"listed_disposable_wins", "tempmail-1.net", workemailvalidator.ListDisposable, "tempmail-1.net", 0, workemailvalidator.CategoryDisposable},
+		{"listed_free_wins", "junkmail.com", workemailvalidator.ListFree, "junkmail.com", 0, workemailvalidator.CategoryFree},
+		{"name_in_label", "contempmail.com", workemailvalidator.ListNone, "", 0, workemailvalidator.CategoryBusiness},
+		{"company_subdomain", "tempmail.acme.com", workemailvalidator.ListNone, "", 0, workemailvalidator.CategoryBusiness},
+		{"company_subdomain_org", "spammail.acme.org", workemailvalidator.ListNone, "", 0, workemailvalidator.CategoryBusiness},
+		{"company_subdomain_suffix", "fake-email.company.co.uk", workemailvalidator.ListNone, "", 0, workemailvalidator.CategoryBusiness},
+		{"numbered_company_subdomain", "tempmail-1.acme.com", workemailvalidator.ListNone, "", 0, workemailvalidator.CategoryBusiness},
+		{"business", "acme.com", workemailvalidator.ListNone, "", 0, workemailvalidator.CategoryBusiness},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := workemailvalidator.Explain(testCase.domain)
+			if got.List != testCase.list || got.Match != testCase.match || got.Depth != testCase.depth || got.Category != testCase.category {
+				t.Errorf("Explain(%q) = %+v, want %v %q at depth %d, %v",
+					testCase.domain, got, testCase.list, testCase.match, testCase.depth, testCase.category)
+			}
+		})
+	}
+
+	if got := workemailvalidator.ListPattern.String(); got != "pattern" {
+		t.Errorf("ListPattern.String() = %q, want %q", got, "pattern")
+	}
+}
+
+// TestPatternChecks tests that pattern matches are disposable for the boolean checks and validation.
+func TestPatternChecks(t *testing.T) {
+	t.Parallel()
+
+	if !workemailvalidator.IsDisposableDomain("tempmail-42.net") || workemailvalidator.IsFreeDomain("tempmail-42.net") {
+		t.Error("a pattern match should be disposable and not free")
+	}
+
+	if !workemailvalidator.IsDisposableOrFreeDomain("tempmail-42.net") || workemailvalidator.IsBusinessDomain("tempmail-42.net") {
+		t.Error("a pattern match should not be a business domain")
+	}
+
+	err := workemailvalidator.ValidateWorkEmail("jane@tempmail-42.net")
+	if !errors.Is(err, workemailvalidator.ErrDisposable) {
+		t.Errorf("ValidateWorkEmail(pattern match) = %v, want ErrDisposable", err)
+	}
+
+	allowed := workemailvalidator.New(workemailvalidator.WithAllowlist("tempmail-42.net"))
+	if !allowed.IsBusinessDomain("tempmail-42.net") {
+		t.Error("the allowlist should override the patterns")
+	}
+}
+
+// TestExplainTLDRisk tests the top-level domain risk tiers reported by Explain.
+func TestExplainTLDRisk(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		domain   string
+		expected workemailvalidator.TLDRisk
+	}{
+		{"standard", "acme.com", workemailvalidator.TLDRiskStandard},
+		{"elevated", "acme.xyz", workemailvalidator.TLDRiskElevated},
+		{"high", "acme.tk", workemailvalidator.TLDRiskHigh},
+		{"case", "ACME.TK", workemailvalidator.TLDRiskHigh},
+		{"listed_domain", "mail.gmail.com", workemailvalidator.TLDRiskStandard},
+		{"listed_high", "mail.tk", workemailvalidator.TLDRiskHigh},
+		{"invalid", "tk", workemailvalidator.TLDRiskStandard},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := workemailvalidator.Explain(testCase.domain).TLDRisk; got != testCase.expected {
+				t.Errorf("Explain(%q).TLDRisk = %v, want %v", testCase.domain, got, testCase.expected)
+			}
+		})
+	}
+
+	if got := workemailvalidator.TLDRiskElevated.String(); got != "elevated" {
+		t.Errorf("TLDRiskElevated.String() = %q, want %q", got, "elevated")
+	}
+}
+
+// TestPatternsWithDisposableDomains tests that a custom disposable list drops the embedded patterns but keeps
+// the risk tiers and custom rules, whatever the order of the options.
+func TestPatternsWithDisposableDomains(t *testing.T) {
+	t.Parallel()
+
+	listOnly := workemailvalidator.New(workemailvalidator.WithDisposableDomains("throwaway.test"))
+	rulesFirst := workemailvalidator.New(
+		workemailvalidator.WithDomainRules("glob inbox-??.*"),
+		workemailvalidator.WithDisposableDomains("throwaway.test"),
+	)
+	rulesLast := workemailvalidator.New(
+		workemailvalidator.WithDisposableDomains("throwaway.test"),
+		workemailvalidator.WithDomainRules("glob inbox-??.*"),
+	)
+
+	tests := []struct {
+		name      string
+		validator *workemailvalidator.Validator
+		domain    string
+		list      workemailvalidator.List
+	}{
+		{"embedded_pattern_dropped", listOnly, "tempmail-42.tk", workemailvalidator.ListNone},
+		{"custom_list", listOnly, "throwaway.test", workemailvalidator.ListDisposable},
+		{"custom_rules_first", rulesFirst, "inbox-ab.com", workemailvalidator.ListPattern},
+		{"custom_rules_last", rulesLast, "inbox-ab.com", workemailvalidator.ListPattern},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.validator.Explain(testCase.domain).List; got != testCase.list {
+				t.Errorf("Explain(%q).List = %v, want %v", testCase.domain, got, testCase.list)
+			}
+		})
+	}
+
+	if got := listOnly.Explain("tempmail-42.tk").TLDRisk; got != workemailvalidator.TLDRiskHigh {
+		t.Errorf("Explain(high-risk TLD).TLDRisk = %v, want %v", got, workemailvalidator.TLDRiskHigh)
+	}
+}
+
+// TestWithDomainRules tests replacing the embedded rules, including malformed rules that are ignored.
+func TestWithDomainRules(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithDomainRules(
+		"glob  inbox-??.*",
+		`glob code[^0-9]\x.test`,
+		`regex drop[0-9]+\.test`,
+		"tld test HIGH",
+		"tld .example elevated",
+		"tld com extreme",
+		"glob [",
+		"regex (",
+		"unknown rule",
+		"",
+	))
+
+	tests := []struct {
+		name     string
+		domain   string
+		list     workemailvalidator.List
+		tldRisk  workemailvalidator.TLDRisk
+		category workemailvalidator.Category
+	}{
+		{"glob", "inbox-ab.com", workemailvalidator.ListPattern, workemailvalidator.TLDRiskStandard, workemailvalidator.CategoryDisposable},
+		{"glob_within_label", "inbox-ab.x.com", workemailvalidator.ListNone, workemailvalidator.TLDRiskStandard, workemailvalidator.CategoryBusiness},
+		{"glob_negated_class", "codeax.test", workemailvalidator.ListPattern, workemailvalidator.TLDRiskHigh, workemailvalidator.CategoryDisposable},
+		{"glob_negated_class_excluded", "code1x.test", workemailvalidator.ListNone, workemailvalidator.TLDRiskHigh, workemailvalidator.CategoryBusiness},
+		{"glob_escaped_letter", "codeaz.test", workemailvalidator.ListNone, workemailvalidator.TLDRiskHigh, workemailvalidator.CategoryBusiness},
+		{"regex", "mx.drop42.test", workemailvalidator.ListPattern, workemailvalidator.TLDRiskHigh, workemailvalidator.CategoryDisposable},
+		{"regex_anchored", "drop42.test.acme.io", workemailvalidator.ListNone, workemailvalidator.TLDRiskStandard, workemailvalidator.CategoryBusiness},
+		{"tld_with_dot", "acme.example", workemailvalidator.ListNone, workemailvalidator.TLDRiskElevated, workemailvalidator.CategoryBusiness},
+		{"embedded_rules_replaced", "tempmail-42.tk", workemailvalidator.ListNone, workemailvalidator.TLDRiskStandard, workemailvalidator.CategoryBusiness},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := validator.Explain(testCase.domain)
+			if got.List != testCase.list || got.TLDRisk != testCase.tldRisk || got.Category != testCase.category {
+				t.Errorf("Explain(%q) = %+v, want %v, %v, %v",
+					testCase.domain, got, testCase.list, testCase.tldRisk, testCase.category)
+			}
+		})
+	}
+}
+
+// TestDomainRulesOrder tests that the first matching rule is reported, whatever the kind and groups of the rules.
+func TestDomainRulesOrder(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithDomainRules(
+		`regex (mail|inbox)-(a|b)\.test`,
+		"glob *-a.test",
+		`regex [a-z]+-[a-c]\.test`,
+	))
+
+	tests := []struct {
+		domain string
+		match  string
+	}{
+		{"mail-a.test", `(mail|inbox)-(a|b)\.test`},
+		{"other-a.test", "*-a.test"},
+		{"inbox-c.test", `[a-z]+-[a-c]\.test`},
+		{"mx.other-b.test", `[a-z]+-[a-c]\.test`},
+	}
+
+	for _, testCase := range tests {
+		if got := validator.Explain(testCase.domain).Match; got != testCase.match {
+			t.Errorf("Explain(%q).Match = %q, want %q", testCase.domain, got, testCase.match)
+		}
+	}
+}
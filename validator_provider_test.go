@@ -0,0 +1,153 @@
+package workemailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// newProviderResolver returns a resolver with one business domain per mailbox provider.
+func newProviderResolver() *workemailvalidator.StaticResolver {
+	return &workemailvalidator.StaticResolver{
+		MX: map[string][]*net.MX{
+			"google.test":     {{Host: "ASPMX.L.GOOGLE.COM.", Pref: 1}, {Host: "alt1.aspmx.l.google.com.", Pref: 5}},
+			"microsoft.test":  {{Host: "microsoft-test.mail.protection.outlook.com.", Pref: 0}},
+			"zoho.test":       {{Host: "mx.zoho.eu.", Pref: 10}},
+			"proton.test":     {{Host: "mail.protonmail.ch.", Pref: 10}},
+			"gateway.test":    {{Host: "eu-smtp-inbound-1.mimecast.com.", Pref: 10}},
+			"selfhosted.test": {{Host: "mail.selfhosted.test.", Pref: 10}},
+			"other.test":      {{Host: "mx.hosting.example.", Pref: 10}},
+			"txtfail.test":    {{Host: "mx.hosting.example.", Pref: 10}},
+		},
+		TXT: map[string][]string{
+			"gateway.test": {"google-site-verification=abc", "v=spf1 include:_spf.mimecast.com ~include:spf.protection.outlook.com -all"},
+			"other.test":   {"v=spf1 include:_spf.hosting.example -all"},
+		},
+		Errors: map[string]error{
+			"timeout.test": &net.DNSError{Err: errTimeout.Error(), Name: "timeout.test", IsTimeout: true},
+		},
+	}
+}
+
+// TestLookupProvider tests identifying the mailbox provider from MX and SPF records.
+func TestLookupProvider(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithResolver(newProviderResolver()))
+
+	tests := []struct {
+		name     string
+		domain   string
+		provider workemailvalidator.Provider
+	}{
+		{"google_mx", "google.test", workemailvalidator.ProviderGoogleWorkspace},
+		{"microsoft_mx", "Microsoft.TEST", workemailvalidator.ProviderMicrosoft365},
+		{"zoho_mx", "zoho.test", workemailvalidator.ProviderZoho},
+		{"proton_mx", "proton.test", workemailvalidator.ProviderProton},
+		{"gateway_spf", "gateway.test", workemailvalidator.ProviderMicrosoft365},
+		{"self_hosted", "selfhosted.test", workemailvalidator.ProviderSelfHosted},
+		{"unknown", "other.test", workemailvalidator.ProviderUnknown},
+		{"no_records", "missing.test", workemailvalidator.ProviderUnknown},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider, err := validator.LookupProvider(context.Background(), testCase.domain)
+			if err != nil {
+				t.Fatalf("LookupProvider(%q) error = %v", testCase.domain, err)
+			}
+
+			if provider != testCase.provider {
+				t.Errorf("LookupProvider(%q) = %v, want %v", testCase.domain, provider, testCase.provider)
+			}
+		})
+	}
+
+	if provider, err := validator.LookupProvider(context.Background(), "timeout.test"); err == nil || provider != workemailvalidator.ProviderUnknown {
+		t.Errorf("LookupProvider(timeout.test) = %v, %v, want ProviderUnknown and an MX lookup error", provider, err)
+	}
+
+	txtFail := workemailvalidator.New(workemailvalidator.WithResolver(&txtFailResolver{newProviderResolver()}))
+
+	if provider, err := txtFail.LookupProvider(context.Background(), "other.test"); !errors.Is(err, errTimeout) || provider != workemailvalidator.ProviderUnknown {
+		t.Errorf("LookupProvider(other.test) = %v, %v, want ProviderUnknown and a TXT lookup error", provider, err)
+	}
+
+	if provider, err := txtFail.LookupProvider(context.Background(), "google.test"); err != nil || provider != workemailvalidator.ProviderGoogleWorkspace {
+		t.Errorf("LookupProvider(google.test) = %v, %v, want a match on MX hosts without a TXT lookup", provider, err)
+	}
+}
+
+// txtFailResolver fails every TXT lookup.
+type txtFailResolver struct {
+	*workemailvalidator.StaticResolver
+}
+
+func (r *txtFailResolver) LookupTXT(context.Context, string) ([]string, error) {
+	return nil, errTimeout
+}
+
+// TestExplainContextProvider tests that the provider is reported alongside the business classification.
+func TestExplainContextProvider(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithResolver(&txtFailResolver{newProviderResolver()}),
+		workemailvalidator.WithProviderCheck(),
+	)
+
+	tests := []struct {
+		name     string
+		domain   string
+		category workemailvalidator.Category
+		mx       workemailvalidator.MXStatus
+		provider workemailvalidator.Provider
+	}{
+		{"google", "google.test", workemailvalidator.CategoryBusiness, workemailvalidator.MXFound, workemailvalidator.ProviderGoogleWorkspace},
+		{"txt_failure_keeps_category", "other.test", workemailvalidator.CategoryBusiness, workemailvalidator.MXFound, workemailvalidator.ProviderUnknown},
+		{"mx_failure", "timeout.test", workemailvalidator.CategoryBusiness, workemailvalidator.MXError, workemailvalidator.ProviderUnknown},
+		{"free_not_checked", "gmail.com", workemailvalidator.CategoryFree, workemailvalidator.MXNotChecked, workemailvalidator.ProviderUnknown},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validator.ExplainContext(context.Background(), testCase.domain)
+			if result.Category != testCase.category || result.MX != testCase.mx || result.Provider != testCase.provider {
+				t.Errorf("ExplainContext(%q) = %+v, want category %v MX %v provider %v",
+					testCase.domain, result, testCase.category, testCase.mx, testCase.provider)
+			}
+		})
+	}
+
+	if result := workemailvalidator.New(workemailvalidator.WithResolver(newProviderResolver())).ExplainContext(context.Background(), "google.test"); result.Provider != workemailvalidator.ProviderUnknown {
+		t.Errorf("ExplainContext without WithProviderCheck reported provider %v", result.Provider)
+	}
+}
+
+// TestProviderString tests the names of the providers.
+func TestProviderString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[workemailvalidator.Provider]string{
+		workemailvalidator.ProviderUnknown:         "unknown",
+		workemailvalidator.ProviderGoogleWorkspace: "google_workspace",
+		workemailvalidator.ProviderMicrosoft365:    "microsoft_365",
+		workemailvalidator.ProviderZoho:            "zoho",
+		workemailvalidator.ProviderProton:          "proton",
+		workemailvalidator.ProviderSelfHosted:      "self_hosted",
+		workemailvalidator.Provider(-1):            "unknown",
+	}
+
+	for provider, expected := range tests {
+		if got := provider.String(); got != expected {
+			t.Errorf("Provider(%d).String() = %q, want %q", int(provider), got, expected)
+		}
+	}
+}
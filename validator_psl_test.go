@@ -0,0 +1,105 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestRegistrableDomain tests eTLD+1 extraction with the embedded Public Suffix List.
+func TestRegistrableDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      error
+	}{
+		{"registrable", "example.com", "example.com", nil},
+		{"subdomain", "mail.example.com", "example.com", nil},
+		{"second_level_suffix", "mail.example.co.uk", "example.co.uk", nil},
+		{"private_suffix", "www.alice.github.io", "alice.github.io", nil},
+		{"wildcard_rule", "a.b.c.ck", "b.c.ck", nil},
+		{"exception_rule", "a.www.ck", "www.ck", nil},
+		{"unlisted_tld", "foo.bar.example", "bar.example", nil},
+		{"normalized", "  Mail.Example.CO.UK ", "example.co.uk", nil},
+		{"unicode", "mail.bücher.de", "xn--bcher-kva.de", nil},
+		{"public_suffix", "co.uk", "", workemailvalidator.ErrPublicSuffix},
+		{"private_public_suffix", "github.io", "", workemailvalidator.ErrPublicSuffix},
+		{"wildcard_public_suffix", "foo.ck", "", workemailvalidator.ErrPublicSuffix},
+		{"tld", "com", "", workemailvalidator.ErrInvalidSyntax},
+		{"empty", "", "", workemailvalidator.ErrInvalidSyntax},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := workemailvalidator.RegistrableDomain(testCase.input)
+			if got != testCase.expected || !errors.Is(err, testCase.err) || (err == nil) != (testCase.err == nil) {
+				t.Errorf("RegistrableDomain(%q) = %q, %v, want %q, %v", testCase.input, got, err, testCase.expected, testCase.err)
+			}
+		})
+	}
+}
+
+// TestPublicSuffixEntries tests that list entries for public suffixes are ignored.
+func TestPublicSuffixEntries(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithDisposableDomains("co.uk", "github.io", "temp.example.org"),
+		workemailvalidator.WithFreeDomains("com"),
+		workemailvalidator.WithBlocklist("org.ua"),
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected workemailvalidator.Category
+	}{
+		{"country_suffix", "acme.co.uk", workemailvalidator.CategoryBusiness},
+		{"private_suffix", "alice.github.io", workemailvalidator.CategoryBusiness},
+		{"tld", "acme.com", workemailvalidator.CategoryBusiness},
+		{"blocklisted_suffix", "acme.org.ua", workemailvalidator.CategoryBusiness},
+		{"registrable_entry", "x.temp.example.org", workemailvalidator.CategoryDisposable},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := validator.Classify(testCase.input); got != testCase.expected {
+				t.Errorf("Classify(%q) = %v, want %v", testCase.input, got, testCase.expected)
+			}
+		})
+	}
+
+	// The embedded feeds list a few public suffixes such as "com.ar"; they must not match whole registries.
+	for _, domain := range []string{"tienda.com.ar", "acme.net.ua", "school.edu.sg"} {
+		if workemailvalidator.IsDisposableDomain(domain) {
+			t.Errorf("IsDisposableDomain(%q) = true, want false", domain)
+		}
+	}
+}
+
+// TestReloaderPublicSuffixEntries tests that file-backed lists ignore public suffixes as well.
+func TestReloaderPublicSuffixEntries(t *testing.T) {
+	t.Parallel()
+
+	disposable := filepath.Join(t.TempDir(), "disposable.txt")
+	writeList(t, disposable, "co.uk\nthrowaway.co.uk\n")
+
+	reloader, err := workemailvalidator.NewReloader(workemailvalidator.WithDisposableFiles(disposable))
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	validator := reloader.Validator()
+	if validator.IsDisposableDomain("acme.co.uk") || !validator.IsDisposableDomain("throwaway.co.uk") {
+		t.Error("the co.uk entry should be ignored and throwaway.co.uk kept")
+	}
+}
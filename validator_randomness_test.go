@@ -0,0 +1,69 @@
+package workemailvalidator_test
+
+import (
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestDetectRandomness tests the character model against names, brands and machine-generated strings.
+func TestDetectRandomness(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		email        string
+		randomLocal  bool
+		randomDomain bool
+	}{
+		{"name", "jane.doe@acme.com", false, false},
+		{"long_name", "christopher.schwartz@acme.com", false, false},
+		{"polish_name", "mkrzysztof@acme.com", false, false},
+		{"vietnamese_name", "nguyen.tran@acme.com", false, false},
+		{"name_with_year", "john1990@acme.com", false, false},
+		{"initials", "jd@acme.com", false, false},
+		{"subaddress", "jane+xk29fjq7@acme.com", false, false},
+		{"brand_domain", "jane@mail.google.com", false, false},
+		{"second_level_suffix", "jane@acme.co.uk", false, false},
+		{"words_and_digits_domain", "jane@newdomain123.xyz", false, false},
+		{"letters_and_digits", "xk29fjq7@acme.com", true, false},
+		{"hex", "A8F3E9C1B2@acme.com", true, false},
+		{"consonants", "qwrtzpl@acme.com", true, false},
+		{"random_domain", "jane@qzvkhtrw.xyz", false, true},
+		{"random_domain_subdomain", "jane@mx.kdjfhgkd.co.uk", false, true},
+		{"bot", "xk29fjq7@qzvkhtrw.xyz", true, true},
+		{"idn_domain", "jane@xn--gmil-63d.com", false, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := workemailvalidator.DetectRandomness(testCase.email)
+			if (got.LocalPart >= 0.5) != testCase.randomLocal || (got.Domain >= 0.5) != testCase.randomDomain {
+				t.Errorf("DetectRandomness(%q) = %+v, want random local part %v, random domain %v",
+					testCase.email, got, testCase.randomLocal, testCase.randomDomain)
+			}
+		})
+	}
+}
+
+// TestDetectRandomnessBounds tests that confidences stay within 0 to 1 and that invalid input scores 0.
+func TestDetectRandomnessBounds(t *testing.T) {
+	t.Parallel()
+
+	if got := workemailvalidator.DetectRandomness("not-an-email"); got != (workemailvalidator.Randomness{}) {
+		t.Errorf("DetectRandomness(invalid) = %+v, want zero", got)
+	}
+
+	if got := workemailvalidator.DetectRandomness("jane@localhost"); got.Domain != 0 {
+		t.Errorf("DetectRandomness(jane@localhost).Domain = %v, want 0", got.Domain)
+	}
+
+	for _, email := range []string{"a8f3e9c1b2@qzvkhtrw.xyz", "zxqvbnkdjfhgkd@xk29fjq7zz.com", "\"x..y\"@acme.com"} {
+		got := workemailvalidator.DetectRandomness(email)
+		if got.LocalPart < 0 || got.LocalPart > 1 || got.Domain < 0 || got.Domain > 1 {
+			t.Errorf("DetectRandomness(%q) = %+v, want confidences within 0 to 1", email, got)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package workemailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestIsRelayDomain tests relay/privacy-alias service detection.
+func TestIsRelayDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []testCase{
+		{"apple_hide_my_email", "privaterelay.appleid.com", true},
+		{"firefox_relay", "mozmail.com", true},
+		{"simplelogin", "SimpleLogin.com", true},
+		{"duckduckgo", "duck.com", true},
+		{"addy_subdomain", "jane.anonaddy.com", true},
+		{"apple_parent", "appleid.com", false},
+		{"free", "gmail.com", false},
+		{"disposable", "mailinator.com", false},
+		{"business", "example.com", false},
+	}
+
+	runDomainTests(t, tests, workemailvalidator.IsRelayDomain)
+}
+
+// TestRelayPrecedence tests that relays win over disposable feeds that list them.
+func TestRelayPrecedence(t *testing.T) {
+	t.Parallel()
+
+	for _, domain := range []string{"mozmail.com", "duck.com", "alias.addy.io"} {
+		result := workemailvalidator.Explain(domain)
+		if result.Category != workemailvalidator.CategoryRelay || result.List != workemailvalidator.ListRelay {
+			t.Errorf("Explain(%q) = %+v, want a relay", domain, result)
+		}
+
+		if workemailvalidator.IsDisposableDomain(domain) || workemailvalidator.IsDisposableOrFreeDomain(domain) || workemailvalidator.IsBusinessDomain(domain) {
+			t.Errorf("%q should be neither disposable, free nor business", domain)
+		}
+	}
+
+	if result := workemailvalidator.Explain("alias.addy.io"); result.Match != "addy.io" || result.Depth != 1 {
+		t.Errorf("Explain(alias.addy.io) = %+v, want match addy.io at depth 1", result)
+	}
+
+	if err := workemailvalidator.ValidateWorkEmail("abc123@privaterelay.appleid.com"); !errors.Is(err, workemailvalidator.ErrRelay) {
+		t.Errorf("ValidateWorkEmail(relay) = %v, want ErrRelay", err)
+	}
+
+	if got := workemailvalidator.CategoryRelay.String(); got != "relay" {
+		t.Errorf("CategoryRelay.String() = %q, want %q", got, "relay")
+	}
+}
+
+// TestWithRelayDomains tests replacing the relay list and overriding it.
+func TestWithRelayDomains(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithRelayDomains("relay.example"))
+
+	if !validator.IsRelayDomain("x.relay.example") || validator.IsRelayDomain("mozmail.com") {
+		t.Error("WithRelayDomains should replace the embedded list")
+	}
+
+	if !validator.IsDisposableDomain("mozmail.com") {
+		t.Error("without the relay entry mozmail.com falls back to the disposable feeds")
+	}
+
+	allowed := workemailvalidator.New(workemailvalidator.WithAllowlist("duck.com"))
+	if allowed.IsRelayDomain("duck.com") || !allowed.IsBusinessDomain("duck.com") {
+		t.Error("the allowlist should override the relay list")
+	}
+}
+
+// TestDisposableMXSkipsRelays tests that custom domains on relay mail servers are not reported as disposable.
+func TestDisposableMXSkipsRelays(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithDisposableMXCheck(),
+		workemailvalidator.WithResolver(&workemailvalidator.StaticResolver{
+			MX: map[string][]*net.MX{"jane.example": {{Host: "mx1.simplelogin.co.", Pref: 10}}},
+		}),
+	)
+
+	if result := validator.ExplainContext(context.Background(), "jane.example"); result.Category != workemailvalidator.CategoryBusiness {
+		t.Errorf("ExplainContext(jane.example) = %+v, want business", result)
+	}
+}
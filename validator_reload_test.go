@@ -0,0 +1,258 @@
+package workemailvalidator_test
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// writeList writes a domain list file for reload tests.
+func writeList(t *testing.T, name, data string) {
+	t.Helper()
+
+	if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReloaderReload tests explicit reloads and the reported change counts.
+func TestReloaderReload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	disposable := filepath.Join(dir, "disposable.txt")
+	free := filepath.Join(dir, "free.txt")
+
+	writeList(t, disposable, "one.com\ntwo.com\n")
+	writeList(t, free, "mail.test\n")
+
+	var stats []workemailvalidator.ReloadStats
+
+	reloader, err := workemailvalidator.NewReloader(
+		workemailvalidator.WithDisposableFiles(disposable),
+		workemailvalidator.WithFreeFiles(free),
+		workemailvalidator.WithValidatorOptions(workemailvalidator.WithAllowlist("two.com")),
+		workemailvalidator.WithReloadCallback(func(s workemailvalidator.ReloadStats) { stats = append(stats, s) }),
+	)
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	validator := reloader.Validator()
+	if !validator.IsDisposableDomain("one.com") || validator.IsDisposableDomain("two.com") || !validator.IsFreeDomain("mail.test") {
+		t.Error("initial load should use the files and the validator options")
+	}
+
+	if validator.IsFreeDomain("gmail.com") {
+		t.Error("file-backed free list should replace the embedded list")
+	}
+
+	writeList(t, disposable, "one.com\nthree.com\nfour.com\n")
+
+	if err := reloader.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	if !reloader.Validator().IsDisposableDomain("three.com") || reloader.Validator().IsDisposableDomain("two.com") {
+		t.Error("reload should swap in the new list")
+	}
+
+	if validator.IsDisposableDomain("three.com") {
+		t.Error("previously returned validator should keep its lists")
+	}
+
+	expected := []workemailvalidator.ReloadStats{
+		{DisposableAdded: 2, DisposableRemoved: 0, FreeAdded: 1, FreeRemoved: 0},
+		{DisposableAdded: 2, DisposableRemoved: 1, FreeAdded: 0, FreeRemoved: 0},
+	}
+
+	if len(stats) != len(expected) || stats[0] != expected[0] || stats[1] != expected[1] {
+		t.Errorf("reload stats = %+v, want %+v", stats, expected)
+	}
+}
+
+// TestReloaderDisposablePatterns tests that file-backed disposable lists drop the embedded name patterns like
+// WithDisposableDomains, and keep custom rules.
+func TestReloaderDisposablePatterns(t *testing.T) {
+	t.Parallel()
+
+	disposable := filepath.Join(t.TempDir(), "disposable.txt")
+	writeList(t, disposable, "one.com\n")
+
+	reloader, err := workemailvalidator.NewReloader(workemailvalidator.WithDisposableFiles(disposable))
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	if list := reloader.Validator().Explain("temp-mail.com").List; list != workemailvalidator.ListNone {
+		t.Errorf("Explain(temp-mail.com).List = %v, want none once the embedded list is replaced", list)
+	}
+
+	custom, err := workemailvalidator.NewReloader(
+		workemailvalidator.WithDisposableFiles(disposable),
+		workemailvalidator.WithValidatorOptions(workemailvalidator.WithDomainRules("glob inbox-??.*")),
+	)
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	if list := custom.Validator().Explain("inbox-ab.com").List; list != workemailvalidator.ListPattern {
+		t.Errorf("Explain(inbox-ab.com).List = %v, want pattern from the custom rules", list)
+	}
+}
+
+// TestReloaderKeepsListsOnError tests that a failed reload keeps the previous lists.
+func TestReloaderKeepsListsOnError(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	disposable := filepath.Join(dir, "disposable.txt")
+	writeList(t, disposable, "one.com\n")
+
+	reloader, err := workemailvalidator.NewReloader(workemailvalidator.WithDisposableFiles(disposable))
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	writeList(t, disposable, "one.com\nnot a domain\n")
+
+	if err := reloader.Reload(); !errors.Is(err, workemailvalidator.ErrMalformedEntry) {
+		t.Errorf("Reload() error = %v, want ErrMalformedEntry", err)
+	}
+
+	if err := os.Remove(disposable); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := reloader.Reload(); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Reload() error = %v, want fs.ErrNotExist", err)
+	}
+
+	if !reloader.Validator().IsDisposableDomain("one.com") {
+		t.Error("failed reload should keep the previous lists")
+	}
+}
+
+// TestNewReloaderErrors tests construction failures.
+func TestNewReloaderErrors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := workemailvalidator.NewReloader(); !errors.Is(err, workemailvalidator.ErrNoReloadFiles) {
+		t.Errorf("NewReloader() error = %v, want ErrNoReloadFiles", err)
+	}
+
+	dir := t.TempDir()
+	free := filepath.Join(dir, "free.txt")
+	writeList(t, free, "bad\n")
+
+	if _, err := workemailvalidator.NewReloader(workemailvalidator.WithFreeFiles(free)); err == nil {
+		t.Error("NewReloader() with malformed file should fail")
+	}
+}
+
+// TestReloaderRun tests that Run picks up changed files and reports errors.
+func TestReloaderRun(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	disposable := filepath.Join(dir, "disposable.txt")
+	writeList(t, disposable, "one.com\n")
+
+	reloads := make(chan workemailvalidator.ReloadStats, 10)
+	errs := make(chan error, 10)
+
+	reloader, err := workemailvalidator.NewReloader(
+		workemailvalidator.WithDisposableFiles(disposable),
+		workemailvalidator.WithPollInterval(5*time.Millisecond),
+		workemailvalidator.WithReloadCallback(func(s workemailvalidator.ReloadStats) { reloads <- s }),
+		workemailvalidator.WithErrorCallback(func(err error) {
+			select {
+			case errs <- err:
+			default: // the broken file is reported on every tick; keep Run from blocking
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	<-reloads // initial load
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		reloader.Run(ctx)
+		close(done)
+	}()
+
+	writeList(t, disposable, "one.com\ntwo.com\n")
+
+	select {
+	case stats := <-reloads:
+		if stats.DisposableAdded != 1 {
+			t.Errorf("DisposableAdded = %d, want 1", stats.DisposableAdded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not reload the changed file")
+	}
+
+	if !reloader.Validator().IsDisposableDomain("two.com") {
+		t.Error("Run should swap in the changed list")
+	}
+
+	writeList(t, disposable, "broken entry\n")
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, workemailvalidator.ErrMalformedEntry) {
+			t.Errorf("error callback got %v, want ErrMalformedEntry", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not report the reload error")
+	}
+
+	cancel()
+	<-done
+}
+
+// TestReloaderConcurrentReads exercises lookups racing with reloads (run with -race).
+func TestReloaderConcurrentReads(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	disposable := filepath.Join(dir, "disposable.txt")
+	writeList(t, disposable, "one.com\n")
+
+	reloader, err := workemailvalidator.NewReloader(workemailvalidator.WithDisposableFiles(disposable))
+	if err != nil {
+		t.Fatalf("NewReloader() error = %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for range 4 {
+		wg.Go(func() {
+			for range 200 {
+				if reloader.Validator().IsWorkEmail("user@one.com") {
+					t.Error("one.com should stay disposable across reloads")
+				}
+			}
+		})
+	}
+
+	for range 20 {
+		if err := reloader.Reload(); err != nil {
+			t.Errorf("Reload() error = %v", err)
+		}
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,195 @@
+package workemailvalidator_test
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// signalsOf returns the signals of the risk score contributions, in order.
+func signalsOf(score workemailvalidator.RiskScore) []workemailvalidator.Signal {
+	signals := make([]workemailvalidator.Signal, 0, len(score.Contributions))
+
+	for _, contribution := range score.Contributions {
+		signals = append(signals, contribution.Signal)
+	}
+
+	return signals
+}
+
+// TestScoreRisk tests the risk score and its contributions with the default weights.
+func TestScoreRisk(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithDisposableDomains("one-feed.test", "two-feeds.test", "all-feeds.test"),
+		workemailvalidator.WithDisposableSources(map[string][]string{
+			"one-feed.test":  {"primary"},
+			"two-feeds.test": {"primary", "community"},
+			"all-feeds.test": {"primary", "community", "wesbos", "kslr"},
+		}),
+		workemailvalidator.WithBlocklist("blocked.test", "kdjfhgkd.test"),
+		workemailvalidator.WithDomainRules("tld tk high", "tld xyz elevated", "glob tempmail-[0-9]*.*"),
+	)
+
+	tests := []struct {
+		name    string
+		email   string
+		score   int
+		signals []workemailvalidator.Signal
+	}{
+		{"business", "jane.doe@acme.com", 0, []workemailvalidator.Signal{}},
+		{"invalid", "not-an-email", 100, []workemailvalidator.Signal{workemailvalidator.SignalInvalid}},
+		{"invalid_domain", "jane@localhost", 100, []workemailvalidator.Signal{workemailvalidator.SignalInvalid}},
+		{"disposable_one_feed", "jane@one-feed.test", 87, []workemailvalidator.Signal{workemailvalidator.SignalDisposable, workemailvalidator.SignalSourceAgreement}},
+		{"disposable_two_feeds", "jane@two-feeds.test", 93, []workemailvalidator.Signal{workemailvalidator.SignalDisposable, workemailvalidator.SignalSourceAgreement}},
+		{"disposable_all_feeds", "jane@all-feeds.test", 100, []workemailvalidator.Signal{workemailvalidator.SignalDisposable, workemailvalidator.SignalSourceAgreement}},
+		{"blocklisted", "jane@blocked.test", 80, []workemailvalidator.Signal{workemailvalidator.SignalDisposable}},
+		{"free", "jane@gmail.com", 30, []workemailvalidator.Signal{workemailvalidator.SignalFree}},
+		{"confusable", "jane@gm\u0430il.com", 90, []workemailvalidator.Signal{workemailvalidator.SignalConfusable}},
+		{"relay", "jane@mozmail.com", 20, []workemailvalidator.Signal{workemailvalidator.SignalRelay}},
+		{"free_typo", "jane@gmaill.com", 40, []workemailvalidator.Signal{workemailvalidator.SignalTypo}},
+		{"tld_typo", "jane@acme.con", 20, []workemailvalidator.Signal{workemailvalidator.SignalTypo}},
+		{"role", "info@acme.com", 15, []workemailvalidator.Signal{workemailvalidator.SignalRole}},
+		{"random_local_part", "a8f3e9c1b2@acme.com", 25, []workemailvalidator.Signal{workemailvalidator.SignalRandomLocalPart}},
+		{"random_domain", "jane@qzvkhtrw.com", 20, []workemailvalidator.Signal{workemailvalidator.SignalRandomDomain}},
+		{"random_both", "xk29fjq7@qzvkhtrw.com", 39, []workemailvalidator.Signal{workemailvalidator.SignalRandomLocalPart, workemailvalidator.SignalRandomDomain}},
+		{"high_risk_tld", "jane@acme.tk", 20, []workemailvalidator.Signal{workemailvalidator.SignalRiskyTLD}},
+		{"elevated_risk_tld", "jane@acme.xyz", 10, []workemailvalidator.Signal{workemailvalidator.SignalRiskyTLD}},
+		{"bot", "xk29fjq7@qzvkhtrw.xyz", 49, []workemailvalidator.Signal{workemailvalidator.SignalRandomLocalPart, workemailvalidator.SignalRandomDomain, workemailvalidator.SignalRiskyTLD}},
+		{"pattern", "jane@tempmail-42.net", 80, []workemailvalidator.Signal{workemailvalidator.SignalDisposable}},
+		{"random_listed_domain", "jane@kdjfhgkd.test", 80, []workemailvalidator.Signal{workemailvalidator.SignalDisposable}},
+		{"role_on_free", "support@gmail.com", 45, []workemailvalidator.Signal{workemailvalidator.SignalFree, workemailvalidator.SignalRole}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			score := validator.ScoreRisk(testCase.email)
+			if score.Score != testCase.score || !slices.Equal(signalsOf(score), testCase.signals) {
+				t.Errorf("ScoreRisk(%q) = %d %v, want %d %v", testCase.email, score.Score, score.Contributions, testCase.score, testCase.signals)
+			}
+		})
+	}
+}
+
+// TestLocalPartRandomness tests that names are not mistaken for machine-generated local parts.
+func TestLocalPartRandomness(t *testing.T) {
+	t.Parallel()
+
+	tests := []testCase{
+		{"name", "jane.doe@acme.com", false},
+		{"long_name", "christopher.schwartz@acme.com", false},
+		{"consonant_cluster", "mkrzysztof@acme.com", false},
+		{"name_with_year", "john1990@acme.com", false},
+		{"short", "jd@acme.com", false},
+		{"letters_and_digits", "xk29fjq7@acme.com", true},
+		{"hex", "a8f3e9c1b2@acme.com", true},
+		{"consonants", "qwrtzpl@acme.com", true},
+	}
+
+	runDomainTests(t, tests, func(email string) bool {
+		return slices.Contains(signalsOf(workemailvalidator.ScoreRisk(email)), workemailvalidator.SignalRandomLocalPart)
+	})
+}
+
+// TestTypoSignal tests that short company domains are not scored as typos of popular providers.
+func TestTypoSignal(t *testing.T) {
+	t.Parallel()
+
+	tests := []testCase{
+		{"two_letters", "jane@ge.com", false},
+		{"two_letters_insertion", "jane@ms.com", false},
+		{"two_letters_substitution", "jane@mt.com", false},
+		{"two_letters_gmx", "jane@gm.com", false},
+		{"three_letters", "jane@aon.com", false},
+		{"three_letters_msn", "jane@mtn.com", false},
+		{"four_letters", "jane@line.com", false},
+		{"tld", "jane@web.dev", false},
+		{"short_slip", "jane@lvie.com", true},
+		{"transposition", "jane@protonmial.com", true},
+	}
+
+	runDomainTests(t, tests, func(email string) bool {
+		return slices.Contains(signalsOf(workemailvalidator.ScoreRisk(email)), workemailvalidator.SignalTypo)
+	})
+
+	if got := workemailvalidator.ScoreRisk("jane@ge.com").Score; got != 0 {
+		t.Errorf("ScoreRisk(short company domain) = %d, want 0", got)
+	}
+}
+
+// TestWithRiskWeights tests changing and disabling signal weights.
+func TestWithRiskWeights(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithRiskWeights(map[workemailvalidator.Signal]float64{
+		workemailvalidator.SignalFree: 60,
+		workemailvalidator.SignalRole: 0,
+	}))
+
+	score := validator.ScoreRisk("support@gmail.com")
+	expected := []workemailvalidator.RiskContribution{{Signal: workemailvalidator.SignalFree, Strength: 1, Points: 60}}
+
+	if score.Score != 60 || !slices.Equal(score.Contributions, expected) {
+		t.Errorf("ScoreRisk() = %d %v, want 60 %v", score.Score, score.Contributions, expected)
+	}
+
+	if got := workemailvalidator.ScoreRisk("support@gmail.com").Score; got != 45 {
+		t.Errorf("default validator score = %d, want 45 with the default weights", got)
+	}
+
+	if got := workemailvalidator.SignalRandomLocalPart.String(); got != "random_local_part" {
+		t.Errorf("SignalRandomLocalPart.String() = %q, want %q", got, "random_local_part")
+	}
+}
+
+// TestScoreRiskContext tests the DNS signals.
+func TestScoreRiskContext(t *testing.T) {
+	t.Parallel()
+
+	resolver := &workemailvalidator.StaticResolver{
+		MX: map[string][]*net.MX{
+			"corp.com":         {{Host: "mx1.corp.com.", Pref: 10}},
+			"fresh-burner.com": {{Host: "mail2.mailinator.com.", Pref: 10}},
+			"nullmx.com":       {{Host: ".", Pref: 0}},
+		},
+	}
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithResolver(resolver),
+		workemailvalidator.WithMXCheck(),
+		workemailvalidator.WithDisposableMXCheck(),
+	)
+
+	tests := []struct {
+		name    string
+		email   string
+		score   int
+		signals []workemailvalidator.Signal
+	}{
+		{"business", "jane@corp.com", 0, []workemailvalidator.Signal{}},
+		{"no_mail_server", "jane@nullmx.com", 70, []workemailvalidator.Signal{workemailvalidator.SignalNoMailServer}},
+		{"disposable_mx", "jane@fresh-burner.com", 80, []workemailvalidator.Signal{workemailvalidator.SignalDisposableMX}},
+		{"no_dns_for_free", "jane@gmail.com", 30, []workemailvalidator.Signal{workemailvalidator.SignalFree}},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			score := validator.ScoreRiskContext(context.Background(), testCase.email)
+			if score.Score != testCase.score || !slices.Equal(signalsOf(score), testCase.signals) {
+				t.Errorf("ScoreRiskContext(%q) = %d %v, want %d %v", testCase.email, score.Score, score.Contributions, testCase.score, testCase.signals)
+			}
+		})
+	}
+
+	if score := validator.ScoreRisk("jane@nullmx.com"); score.Score != 0 {
+		t.Errorf("ScoreRisk() = %d, want 0 without DNS checks", score.Score)
+	}
+}
@@ -0,0 +1,123 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestIsRoleAccount tests role account detection on the local part.
+func TestIsRoleAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []testCase{
+		{"info", "info@corp.com", true},
+		{"uppercase", "SALES@corp.com", true},
+		{"noreply", "noreply@corp.com", true},
+		{"no_reply_hyphen", "no-reply@corp.com", true},
+		{"no_reply_dot", "No.Reply@corp.com", true},
+		{"subaddress", "support+billing@corp.com", true},
+		{"quoted", `"admin"@corp.com`, true},
+		{"display_name", "Sales Team <sales@corp.com>", true},
+		{"free_domain", "admin@gmail.com", true},
+		{"person", "jane.doe@corp.com", false},
+		{"role_prefix", "information@corp.com", false},
+		{"role_in_tag", "jane+info@corp.com", false},
+		{"invalid", "info@", false},
+	}
+
+	runDomainTests(t, tests, workemailvalidator.IsRoleAccount)
+}
+
+// TestWithRoleAccounts tests replacing and extending the role account list.
+func TestWithRoleAccounts(t *testing.T) {
+	t.Parallel()
+
+	replaced := workemailvalidator.New(workemailvalidator.WithRoleAccounts("Purchasing", ""))
+	extended := workemailvalidator.New(workemailvalidator.WithExtraRoleAccounts("purchasing"))
+
+	tests := []struct {
+		name     string
+		email    string
+		replaced bool
+		extended bool
+	}{
+		{"custom", "purchasing@corp.com", true, true},
+		{"embedded", "info@corp.com", false, true},
+		{"person", "jane@corp.com", false, false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := replaced.IsRoleAccount(testCase.email); got != testCase.replaced {
+				t.Errorf("replaced.IsRoleAccount(%q) = %v, want %v", testCase.email, got, testCase.replaced)
+			}
+
+			if got := extended.IsRoleAccount(testCase.email); got != testCase.extended {
+				t.Errorf("extended.IsRoleAccount(%q) = %v, want %v", testCase.email, got, testCase.extended)
+			}
+		})
+	}
+}
+
+// TestExplainEmailRole tests that the role flag is reported on email results only.
+func TestExplainEmailRole(t *testing.T) {
+	t.Parallel()
+
+	if result := workemailvalidator.ExplainEmail("info@example.com"); !result.Role || result.Category != workemailvalidator.CategoryBusiness {
+		t.Errorf("ExplainEmail(info@example.com) = %+v, want a business role account", result)
+	}
+
+	if result := workemailvalidator.ExplainEmail("jane@example.com"); result.Role {
+		t.Errorf("ExplainEmail(jane@example.com) = %+v, want Role false", result)
+	}
+
+	if result := workemailvalidator.Explain("info.example.com"); result.Role {
+		t.Errorf("Explain(info.example.com) = %+v, want Role false", result)
+	}
+}
+
+// TestWithRoleAccountRejection tests that role accounts are rejected only when enabled.
+func TestWithRoleAccountRejection(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithRoleAccountRejection())
+
+	tests := []struct {
+		name    string
+		email   string
+		lenient error
+		strict  error
+	}{
+		{"role_business", "sales@example.com", nil, workemailvalidator.ErrRoleAccount},
+		{"person_business", "jane@example.com", nil, nil},
+		{"role_free", "info@gmail.com", workemailvalidator.ErrFreeProvider, workemailvalidator.ErrFreeProvider},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := workemailvalidator.ValidateWorkEmail(testCase.email); !errors.Is(err, testCase.lenient) || (err == nil) != (testCase.lenient == nil) {
+				t.Errorf("ValidateWorkEmail(%q) = %v, want %v", testCase.email, err, testCase.lenient)
+			}
+
+			err := validator.ValidateWorkEmail(testCase.email)
+			if !errors.Is(err, testCase.strict) || (err == nil) != (testCase.strict == nil) {
+				t.Errorf("strict ValidateWorkEmail(%q) = %v, want %v", testCase.email, err, testCase.strict)
+			}
+
+			if got := validator.IsWorkEmail(testCase.email); got != (testCase.strict == nil) {
+				t.Errorf("strict IsWorkEmail(%q) = %v, want %v", testCase.email, got, testCase.strict == nil)
+			}
+		})
+	}
+
+	var validationErr *workemailvalidator.ValidationError
+	if err := validator.ValidateWorkEmail("sales@example.com"); !errors.As(err, &validationErr) || !validationErr.Result.Role {
+		t.Errorf("ValidateWorkEmail(sales@example.com) = %v, want a ValidationError with a role Result", err)
+	}
+}
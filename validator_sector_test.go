@@ -0,0 +1,118 @@
+package workemailvalidator_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestClassifySector tests the suffix rules and the university list.
+func TestClassifySector(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		domain string
+		sector workemailvalidator.Sector
+	}{
+		{"edu", "mit.edu", workemailvalidator.SectorAcademic},
+		{"edu_subdomain", "CS.Stanford.EDU", workemailvalidator.SectorAcademic},
+		{"ac_uk", "ox.ac.uk", workemailvalidator.SectorAcademic},
+		{"edu_au", "unimelb.edu.au", workemailvalidator.SectorAcademic},
+		{"listed_university", "inf.ethz.ch", workemailvalidator.SectorAcademic},
+		{"gov", "nasa.gov", workemailvalidator.SectorGovernment},
+		{"gov_uk", "hmrc.gov.uk", workemailvalidator.SectorGovernment},
+		{"gouv_fr", "interieur.gouv.fr", workemailvalidator.SectorGovernment},
+		{"go_jp", "mofa.go.jp", workemailvalidator.SectorGovernment},
+		{"listed_government", "bmi.bund.de", workemailvalidator.SectorGovernment},
+		{"mil", "army.mil", workemailvalidator.SectorMilitary},
+		{"mod_uk", "mod.uk", workemailvalidator.SectorMilitary},
+		{"corporate", "example.com", workemailvalidator.SectorCorporate},
+		{"ascension_island", "foo.ac", workemailvalidator.SectorCorporate},
+		{"ac_under_generic_tld", "ac.com", workemailvalidator.SectorCorporate},
+		{"free", "gmail.com", workemailvalidator.SectorUnknown},
+		{"disposable", "temp-mail.org", workemailvalidator.SectorUnknown},
+		{"invalid", "edu", workemailvalidator.SectorUnknown},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := workemailvalidator.ClassifySector(testCase.domain); got != testCase.sector {
+				t.Errorf("ClassifySector(%q) = %v, want %v", testCase.domain, got, testCase.sector)
+			}
+		})
+	}
+}
+
+// TestClassifySectorEmail tests sectors of email addresses and overrides.
+func TestClassifySectorEmail(t *testing.T) {
+	t.Parallel()
+
+	if got := workemailvalidator.ClassifySectorEmail("Jane <jane@ox.ac.uk>"); got != workemailvalidator.SectorAcademic {
+		t.Errorf("ClassifySectorEmail(jane@ox.ac.uk) = %v, want academic", got)
+	}
+
+	if got := workemailvalidator.ClassifySectorEmail("jane@"); got != workemailvalidator.SectorUnknown {
+		t.Errorf("ClassifySectorEmail(jane@) = %v, want unknown", got)
+	}
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithUniversityDomains("campus.example"),
+		workemailvalidator.WithAllowlist("partner.gov"),
+		workemailvalidator.WithBlocklist("bad.edu"),
+	)
+
+	tests := map[string]workemailvalidator.Sector{
+		"campus.example": workemailvalidator.SectorAcademic,
+		"ethz.ch":        workemailvalidator.SectorCorporate,
+		"partner.gov":    workemailvalidator.SectorGovernment,
+		"bad.edu":        workemailvalidator.SectorUnknown,
+	}
+
+	for domain, sector := range tests {
+		if got := validator.ClassifySector(domain); got != sector {
+			t.Errorf("ClassifySector(%q) = %v, want %v", domain, got, sector)
+		}
+	}
+}
+
+// TestExplainContextSector tests that domains that fail the MX check lose their sector.
+func TestExplainContextSector(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithMXCheck(),
+		workemailvalidator.WithResolver(&workemailvalidator.StaticResolver{
+			MX: map[string][]*net.MX{"nullmx.gov": {{Host: ".", Pref: 0}}},
+		}),
+	)
+
+	result := validator.ExplainContext(context.Background(), "nullmx.gov")
+	if result.Category != workemailvalidator.CategoryNoMail || result.Sector != workemailvalidator.SectorUnknown {
+		t.Errorf("ExplainContext(nullmx.gov) = %+v, want no_mail with an unknown sector", result)
+	}
+}
+
+// TestSectorString tests the names of the sectors.
+func TestSectorString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[workemailvalidator.Sector]string{
+		workemailvalidator.SectorUnknown:    "unknown",
+		workemailvalidator.SectorCorporate:  "corporate",
+		workemailvalidator.SectorAcademic:   "academic",
+		workemailvalidator.SectorGovernment: "government",
+		workemailvalidator.SectorMilitary:   "military",
+		workemailvalidator.Sector(-1):       "unknown",
+	}
+
+	for sector, expected := range tests {
+		if got := sector.String(); got != expected {
+			t.Errorf("Sector(%d).String() = %q, want %q", int(sector), got, expected)
+		}
+	}
+}
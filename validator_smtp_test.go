@@ -0,0 +1,386 @@
+package workemailvalidator_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// fakeSMTPServer is a minimal SMTP server that answers RCPT TO from a list of mailboxes.
+type fakeSMTPServer struct {
+	listener net.Listener
+	// mailboxes are the recipients that exist; all others are rejected unless catchAll is set.
+	mailboxes map[string]bool
+	catchAll  bool
+	// rcptReply, if set, is sent for every RCPT TO.
+	rcptReply string
+	// greylist is the number of RCPT TO commands deferred before answering normally.
+	greylist atomic.Int32
+	// tlsConfig enables STARTTLS.
+	tlsConfig *tls.Config
+	// usedTLS reports whether the last RCPT TO was received over TLS.
+	usedTLS atomic.Bool
+	wg      sync.WaitGroup
+}
+
+// startFakeSMTPServer starts a server on a local port and stops it when the test ends.
+func startFakeSMTPServer(t *testing.T, server *fakeSMTPServer) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	server.listener = listener
+
+	server.wg.Go(func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			server.wg.Go(func() { server.serve(conn) })
+		}
+	})
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+		server.wg.Wait()
+	})
+
+	return server
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	secure := false
+
+	reply := func(lines ...string) {
+		_, _ = conn.Write([]byte(strings.Join(lines, "\r\n") + "\r\n"))
+	}
+
+	reply("220 fake.test ESMTP")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			if s.tlsConfig != nil && !secure {
+				reply("250-fake.test", "250-STARTTLS", "250 8BITMIME")
+			} else {
+				reply("250-fake.test", "250 8BITMIME")
+			}
+		case command == "STARTTLS":
+			reply("220 2.0.0 ready")
+
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+
+			conn, reader, secure = tlsConn, bufio.NewReader(tlsConn), true
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			reply("250 2.1.0 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			s.usedTLS.Store(secure)
+			reply(s.rcptResponse(strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")))
+		case command == "QUIT":
+			reply("221 2.0.0 bye")
+			return
+		default:
+			reply("502 5.5.2 not implemented")
+		}
+	}
+}
+
+func (s *fakeSMTPServer) rcptResponse(recipient string) string {
+	switch {
+	case s.rcptReply != "":
+		return s.rcptReply
+	case s.greylist.Add(-1) >= 0:
+		return "451 4.7.1 greylisted, try again later"
+	case s.catchAll || s.mailboxes[recipient]:
+		return "250 2.1.5 OK"
+	default:
+		return "550 5.1.1 no such user"
+	}
+}
+
+// newProber returns a Prober that resolves MX hosts of the test domains and connects to the fake servers.
+func newProber(servers map[string]*fakeSMTPServer, opts ...workemailvalidator.ProberOption) *workemailvalidator.Prober {
+	resolver := &workemailvalidator.StaticResolver{
+		MX: map[string][]*net.MX{
+			"nullmx.test": {{Host: ".", Pref: 0}},
+			"backup.test": {{Host: "down.backup.test.", Pref: 5}, {Host: "mx.backup.test.", Pref: 10}},
+		},
+	}
+
+	for host := range servers {
+		domain := strings.TrimPrefix(host, "mx.")
+		if _, ok := resolver.MX[domain]; !ok {
+			resolver.MX[domain] = []*net.MX{{Host: host + ".", Pref: 10}}
+		}
+	}
+
+	dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, _, _ := net.SplitHostPort(address)
+
+		server, ok := servers[host]
+		if !ok {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+		}
+
+		var dialer net.Dialer
+
+		return dialer.DialContext(ctx, network, server.listener.Addr().String())
+	}
+
+	opts = append([]workemailvalidator.ProberOption{
+		workemailvalidator.WithProbeValidator(workemailvalidator.New(workemailvalidator.WithResolver(resolver))),
+		workemailvalidator.WithProbeDialer(dial),
+		workemailvalidator.WithProbeTimeout(2 * time.Second),
+		workemailvalidator.WithHelloName("probe.test"),
+	}, opts...)
+
+	return workemailvalidator.NewProber(opts...)
+}
+
+// TestProbe tests the verdicts for existing, missing and catch-all mailboxes.
+func TestProbe(t *testing.T) {
+	t.Parallel()
+
+	servers := map[string]*fakeSMTPServer{
+		"mx.corp.test":     startFakeSMTPServer(t, &fakeSMTPServer{mailboxes: map[string]bool{"jdoe@corp.test": true}}),
+		"mx.catchall.test": startFakeSMTPServer(t, &fakeSMTPServer{catchAll: true}),
+		"mx.blocked.test":  startFakeSMTPServer(t, &fakeSMTPServer{rcptReply: "550 5.7.1 client host blocked"}),
+		"mx.backup.test":   startFakeSMTPServer(t, &fakeSMTPServer{mailboxes: map[string]bool{"jdoe@backup.test": true}}),
+	}
+	prober := newProber(servers)
+
+	tests := []struct {
+		name           string
+		email          string
+		deliverability workemailvalidator.Deliverability
+		host           string
+		code           int
+	}{
+		{"deliverable", "Jane <jdoe@corp.test>", workemailvalidator.Deliverable, "mx.corp.test", 250},
+		{"undeliverable", "nobody@corp.test", workemailvalidator.Undeliverable, "mx.corp.test", 550},
+		{"catch_all", "anyone@catchall.test", workemailvalidator.CatchAll, "mx.catchall.test", 250},
+		{"policy_block", "jdoe@blocked.test", workemailvalidator.DeliverabilityUnknown, "mx.blocked.test", 550},
+		{"next_mx_host", "jdoe@backup.test", workemailvalidator.Deliverable, "mx.backup.test", 250},
+		{"null_mx", "jdoe@nullmx.test", workemailvalidator.Undeliverable, "", 0},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := prober.Probe(context.Background(), testCase.email)
+			if err != nil {
+				t.Fatalf("Probe(%q) error = %v", testCase.email, err)
+			}
+
+			if result.Deliverability != testCase.deliverability || result.Host != testCase.host || result.Code != testCase.code {
+				t.Errorf("Probe(%q) = %+v, want %v from %q with code %d",
+					testCase.email, result, testCase.deliverability, testCase.host, testCase.code)
+			}
+		})
+	}
+}
+
+// TestProbeCatchAllLocalPart tests that the catch-all check probes the configured local part.
+func TestProbeCatchAllLocalPart(t *testing.T) {
+	t.Parallel()
+
+	servers := map[string]*fakeSMTPServer{
+		"mx.corp.test": startFakeSMTPServer(t, &fakeSMTPServer{mailboxes: map[string]bool{
+			"jdoe@corp.test":    true,
+			"postbox@corp.test": true,
+		}}),
+	}
+
+	tests := []struct {
+		name           string
+		localPart      string
+		deliverability workemailvalidator.Deliverability
+	}{
+		{"existing_local_part", "postbox", workemailvalidator.CatchAll},
+		{"missing_local_part", "nobody", workemailvalidator.Deliverable},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			prober := newProber(servers, workemailvalidator.WithCatchAllLocalPart(func() string { return testCase.localPart }))
+
+			result, err := prober.Probe(context.Background(), "jdoe@corp.test")
+			if err != nil || result.Deliverability != testCase.deliverability {
+				t.Errorf("Probe() = %+v, %v, want %v", result, err, testCase.deliverability)
+			}
+		})
+	}
+}
+
+// TestProbeErrors tests that invalid addresses and unreachable servers are reported as errors.
+func TestProbeErrors(t *testing.T) {
+	t.Parallel()
+
+	prober := newProber(map[string]*fakeSMTPServer{})
+
+	if _, err := prober.Probe(context.Background(), "not an address"); !errors.Is(err, workemailvalidator.ErrInvalidAddress) {
+		t.Errorf("Probe(invalid) error = %v, want ErrInvalidAddress", err)
+	}
+
+	result, err := prober.Probe(context.Background(), "jdoe@backup.test")
+	if !errors.Is(err, workemailvalidator.ErrProbeFailed) || result.Deliverability != workemailvalidator.DeliverabilityUnknown {
+		t.Errorf("Probe(unreachable) = %+v, %v, want DeliverabilityUnknown and ErrProbeFailed", result, err)
+	}
+
+	timeout := errors.New("i/o timeout")
+	resolver := &workemailvalidator.StaticResolver{Errors: map[string]error{"broken.test": timeout}}
+	prober = workemailvalidator.NewProber(
+		workemailvalidator.WithProbeValidator(workemailvalidator.New(workemailvalidator.WithResolver(resolver))),
+	)
+
+	result, err = prober.Probe(context.Background(), "jdoe@broken.test")
+	if !errors.Is(err, workemailvalidator.ErrProbeFailed) || !errors.Is(err, timeout) ||
+		result.Deliverability != workemailvalidator.DeliverabilityUnknown {
+		t.Errorf("Probe(MX lookup failure) = %+v, %v, want DeliverabilityUnknown and ErrProbeFailed", result, err)
+	}
+}
+
+// TestProbeGreylisting tests that deferred recipients are reported, or retried when configured.
+func TestProbeGreylisting(t *testing.T) {
+	t.Parallel()
+
+	newServer := func() *fakeSMTPServer {
+		server := startFakeSMTPServer(t, &fakeSMTPServer{mailboxes: map[string]bool{"jdoe@grey.test": true}})
+		server.greylist.Store(1)
+
+		return server
+	}
+
+	result, err := newProber(map[string]*fakeSMTPServer{"mx.grey.test": newServer()}).Probe(context.Background(), "jdoe@grey.test")
+	if err != nil || !result.Greylisted || result.Deliverability != workemailvalidator.DeliverabilityUnknown || result.Code != 451 {
+		t.Errorf("Probe without retry = %+v, %v, want greylisted DeliverabilityUnknown", result, err)
+	}
+
+	prober := newProber(map[string]*fakeSMTPServer{"mx.grey.test": newServer()}, workemailvalidator.WithGreylistRetry(10*time.Millisecond))
+
+	result, err = prober.Probe(context.Background(), "jdoe@grey.test")
+	if err != nil || result.Greylisted || result.Deliverability != workemailvalidator.Deliverable {
+		t.Errorf("Probe with retry = %+v, %v, want Deliverable", result, err)
+	}
+}
+
+// TestProbeStartTLS tests that STARTTLS is used when offered, and that a failed handshake only falls back to
+// plain text with WithPlaintextFallback.
+func TestProbeStartTLS(t *testing.T) {
+	t.Parallel()
+
+	certificate, roots := newTestCertificate(t, "mx.tls.test")
+	server := startFakeSMTPServer(t, &fakeSMTPServer{
+		mailboxes: map[string]bool{"jdoe@tls.test": true},
+		tlsConfig: &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12},
+	})
+	servers := map[string]*fakeSMTPServer{"mx.tls.test": server}
+
+	trusting := newProber(servers, workemailvalidator.WithProbeTLSConfig(&tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}))
+
+	result, err := trusting.Probe(context.Background(), "jdoe@tls.test")
+	if err != nil || result.Deliverability != workemailvalidator.Deliverable || !server.usedTLS.Load() {
+		t.Errorf("Probe over STARTTLS = %+v, %v (TLS %v), want Deliverable over TLS", result, err, server.usedTLS.Load())
+	}
+
+	result, err = newProber(servers).Probe(context.Background(), "jdoe@tls.test")
+	if !errors.Is(err, workemailvalidator.ErrProbeFailed) || result.Deliverability != workemailvalidator.DeliverabilityUnknown {
+		t.Errorf("Probe with untrusted certificate = %+v, %v, want DeliverabilityUnknown and ErrProbeFailed", result, err)
+	}
+
+	result, err = newProber(servers, workemailvalidator.WithPlaintextFallback()).Probe(context.Background(), "jdoe@tls.test")
+	if err != nil || result.Deliverability != workemailvalidator.Deliverable || server.usedTLS.Load() {
+		t.Errorf("Probe with plain text fallback = %+v, %v (TLS %v), want Deliverable in plain text",
+			result, err, server.usedTLS.Load())
+	}
+}
+
+// newTestCertificate returns a self-signed certificate for the host and a pool trusting it.
+func newTestCertificate(t *testing.T, host string) (tls.Certificate, *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     []string{host},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, roots
+}
+
+// TestDeliverabilityString tests the names of the verdicts.
+func TestDeliverabilityString(t *testing.T) {
+	t.Parallel()
+
+	tests := map[workemailvalidator.Deliverability]string{
+		workemailvalidator.DeliverabilityUnknown: "unknown",
+		workemailvalidator.Deliverable:           "deliverable",
+		workemailvalidator.Undeliverable:         "undeliverable",
+		workemailvalidator.CatchAll:              "catch_all",
+		workemailvalidator.Deliverability(-1):    "unknown",
+	}
+
+	for deliverability, expected := range tests {
+		if got := deliverability.String(); got != expected {
+			t.Errorf("Deliverability(%d).String() = %q, want %q", int(deliverability), got, expected)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package workemailvalidator_test
+
+import (
+	"slices"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// newSourcesValidator builds a validator with a small disposable list and known provenance.
+func newSourcesValidator(opts ...workemailvalidator.Option) *workemailvalidator.Validator {
+	base := []workemailvalidator.Option{
+		workemailvalidator.WithDisposableDomains("agreed.com", "noisy.com", "unknown.com"),
+		workemailvalidator.WithDisposableSources(map[string][]string{
+			"agreed.com": {"primary", "fakefilter", "primary", " wesbos "},
+			"NOISY.com":  {"kslr"},
+		}),
+	}
+
+	return workemailvalidator.New(append(base, opts...)...)
+}
+
+// TestSources tests that Sources reports the feeds behind the matching disposable entry.
+func TestSources(t *testing.T) {
+	t.Parallel()
+
+	validator := newSourcesValidator()
+
+	tests := []struct {
+		name     string
+		domain   string
+		expected []string
+	}{
+		{"exact", "agreed.com", []string{"fakefilter", "primary", "wesbos"}},
+		{"subdomain", "mail.agreed.com", []string{"fakefilter", "primary", "wesbos"}},
+		{"single_source", "NOISY.COM", []string{"kslr"}},
+		{"no_provenance", "unknown.com", nil},
+		{"not_disposable", "example.com", nil},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := validator.Sources(testCase.domain); !slices.Equal(got, testCase.expected) {
+				t.Errorf("Sources(%q) = %v, want %v", testCase.domain, got, testCase.expected)
+			}
+		})
+	}
+}
+
+// TestSourcesReturnsCopy ensures callers cannot modify the validator's provenance data.
+func TestSourcesReturnsCopy(t *testing.T) {
+	t.Parallel()
+
+	validator := newSourcesValidator()
+
+	sources := validator.Sources("agreed.com")
+	sources[0] = "modified"
+
+	if got := validator.Sources("agreed.com"); got[0] != "fakefilter" {
+		t.Errorf("Sources() returned shared slice, got %v after modification", got)
+	}
+}
+
+// TestWithMinSources tests that entries with too few sources are not treated as disposable.
+func TestWithMinSources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		minSources int
+		domain     string
+		expected   bool
+	}{
+		{"default_agreed", 0, "agreed.com", true},
+		{"default_noisy", 0, "noisy.com", true},
+		{"default_unknown", 0, "unknown.com", true},
+		{"two_agreed", 2, "agreed.com", true},
+		{"two_agreed_subdomain", 2, "x.agreed.com", true},
+		{"two_noisy", 2, "noisy.com", false},
+		{"two_unknown_kept", 2, "unknown.com", true},
+		{"three_agreed", 3, "agreed.com", true},
+		{"four_agreed", 4, "agreed.com", false},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			validator := newSourcesValidator(workemailvalidator.WithMinSources(testCase.minSources))
+
+			if got := validator.IsDisposableDomain(testCase.domain); got != testCase.expected {
+				t.Errorf("IsDisposableDomain(%q) with %d min sources = %v, want %v",
+					testCase.domain, testCase.minSources, got, testCase.expected)
+			}
+
+			wantCategory := workemailvalidator.CategoryBusiness
+			if testCase.expected {
+				wantCategory = workemailvalidator.CategoryDisposable
+			}
+
+			if got := validator.Classify(testCase.domain); got != wantCategory {
+				t.Errorf("Classify(%q) with %d min sources = %v, want %v",
+					testCase.domain, testCase.minSources, got, wantCategory)
+			}
+		})
+	}
+}
+
+// TestEmbeddedSources ensures WithMinSources keeps the embedded feed domains whose provenance is not recorded.
+func TestEmbeddedSources(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithMinSources(2))
+
+	if !validator.IsDisposableDomain("mailinator.com") {
+		t.Error("IsDisposableDomain(mailinator.com) = false with WithMinSources(2), want true")
+	}
+
+	if validator.IsWorkEmail("a@mailinator.com") {
+		t.Error("IsWorkEmail(a@mailinator.com) = true with WithMinSources(2), want false")
+	}
+
+	if got := workemailvalidator.Sources("example.com"); got != nil {
+		t.Errorf("Sources(%q) = %v, want nil for a business domain", "example.com", got)
+	}
+}
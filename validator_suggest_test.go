@@ -0,0 +1,140 @@
+package workemailvalidator_test
+
+import (
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestSuggestDomain tests corrections of mistyped domains.
+func TestSuggestDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		domain     string
+		suggestion string
+	}{
+		{"transposition", "gmial.com", "gmail.com"},
+		{"transposition_hotmail", "HOTMIAL.com", "hotmail.com"},
+		{"missing_letter", "outlok.com", "outlook.com"},
+		{"extra_letter", "gmaill.com", "gmail.com"},
+		{"adjacent_key", "gnail.com", "gmail.com"},
+		{"two_typos_long_domain", "hotmaill.co", "hotmail.com"},
+		{"unknown_tld", "gmail.con", "gmail.com"},
+		{"unknown_tld_business", "acme.con", "acme.com"},
+		{"missing_tld_letter", "outlook.cm", "outlook.com"},
+		{"free_provider", "gmail.com", ""},
+		{"free_subdomain", "mail.yahoo.com", ""},
+		{"business", "stripe.com", ""},
+		{"short_business", "acme.io", ""},
+		{"short_label_substitution", "ge.com", ""},
+		{"short_label_insertion", "ms.com", ""},
+		{"short_label_two_letters", "mt.com", ""},
+		{"short_label_gmx", "gm.com", ""},
+		{"short_label_aol", "aon.com", ""},
+		{"short_label_msn", "mtn.com", ""},
+		{"short_label_live", "line.com", ""},
+		{"short_label_tld", "web.dev", ""},
+		{"short_label_transposition", "lvie.com", "live.com"},
+		{"short_label_adjacent_key", "aok.com", "aol.com"},
+		{"invalid", "gmail", ""},
+		{"empty", "", ""},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			suggestion, ok := workemailvalidator.SuggestDomain(testCase.domain)
+			if suggestion != testCase.suggestion || ok != (testCase.suggestion != "") {
+				t.Errorf("SuggestDomain(%q) = %q, %v, want %q", testCase.domain, suggestion, ok, testCase.suggestion)
+			}
+		})
+	}
+}
+
+// TestSuggest tests corrections of email addresses.
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		email      string
+		suggestion string
+	}{
+		{"typo", "jane@gmial.com", "jane@gmail.com"},
+		{"display_name", "Jane Doe <jane.doe@outlok.com>", "jane.doe@outlook.com"},
+		{"correct", "jane@gmail.com", ""},
+		{"invalid", "jane@@gmial.com", ""},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			suggestion, ok := workemailvalidator.Suggest(testCase.email)
+			if suggestion != testCase.suggestion || ok != (testCase.suggestion != "") {
+				t.Errorf("Suggest(%q) = %q, %v, want %q", testCase.email, suggestion, ok, testCase.suggestion)
+			}
+		})
+	}
+}
+
+// TestSuggestAllowlist tests that allowlisted domains are never corrected.
+func TestSuggestAllowlist(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithAllowlist("gmial.com"))
+
+	if suggestion, ok := validator.SuggestDomain("gmial.com"); ok {
+		t.Errorf("SuggestDomain(allowlisted) = %q, want no suggestion", suggestion)
+	}
+}
+
+// TestWithTypoDetection tests that likely typos of free providers are classified as free.
+func TestWithTypoDetection(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(
+		workemailvalidator.WithTypoDetection(),
+		workemailvalidator.WithDisposableDomains(),
+	)
+
+	tests := []struct {
+		name     string
+		domain   string
+		category workemailvalidator.Category
+		list     workemailvalidator.List
+		match    string
+	}{
+		{"typo", "gmial.com", workemailvalidator.CategoryFree, workemailvalidator.ListFreeTypo, "gmail.com"},
+		{"typo_tld", "hotmail.con", workemailvalidator.CategoryFree, workemailvalidator.ListFreeTypo, "hotmail.com"},
+		{"free", "gmail.com", workemailvalidator.CategoryFree, workemailvalidator.ListFree, "gmail.com"},
+		{"business_tld_typo", "acme.con", workemailvalidator.CategoryBusiness, workemailvalidator.ListNone, ""},
+		{"business", "stripe.com", workemailvalidator.CategoryBusiness, workemailvalidator.ListNone, ""},
+		{"short_business", "ge.com", workemailvalidator.CategoryBusiness, workemailvalidator.ListNone, ""},
+		{"short_business_aol", "aon.com", workemailvalidator.CategoryBusiness, workemailvalidator.ListNone, ""},
+		{"short_slip", "lvie.com", workemailvalidator.CategoryFree, workemailvalidator.ListFreeTypo, "live.com"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := validator.Explain(testCase.domain)
+			if result.Category != testCase.category || result.List != testCase.list || result.Match != testCase.match {
+				t.Errorf("Explain(%q) = %+v, want category %v list %v match %q",
+					testCase.domain, result, testCase.category, testCase.list, testCase.match)
+			}
+
+			if got, want := validator.IsFreeDomain(testCase.domain), testCase.category == workemailvalidator.CategoryFree; got != want {
+				t.Errorf("IsFreeDomain(%q) = %v, want %v", testCase.domain, got, want)
+			}
+		})
+	}
+
+	if !workemailvalidator.New(workemailvalidator.WithDisposableDomains()).IsBusinessDomain("gmial.com") {
+		t.Error("typos should be business domains unless WithTypoDetection is given")
+	}
+}
@@ -232,11 +232,11 @@ func TestIsWorkEmail(t *testing.T) {
 		{"only_at", "@", false},
 		{"at_at_start", "@domain.com", false},
 		{"at_at_end", "user@", false},
-		// Multiple @ signs - domain extraction uses LastIndexByte
-		// "user@@domain.com" -> "domain.com" (valid and business)
-		// "user@domain@com" -> "com" (invalid - TLD only, fails validation)
-		{"multiple_at", "user@@domain.com", true},
+		// Multiple @ signs - an unquoted local part cannot contain '@'
+		{"multiple_at", "user@@domain.com", false},
 		{"multiple_at_2", "user@domain@com", false},
+		{"quoted_at_in_local", `"a@b"@example.com`, true},
+		{"quoted_at_in_local_free", `"a@b"@gmail.com`, false},
 
 		// Edge cases with @ symbol
 		{"just_domain", "domain.com", false},
@@ -262,12 +262,24 @@ func TestIsWorkEmail(t *testing.T) {
 		{"subdomain_free", "user@mail.gmail.com", false},
 		{"subdomain_disposable", "user@x.temp-mail.com", false},
 
-		// Multiple @ signs - uses LastIndexByte, so extracts domain after last @
-		// "user@host@company.com" extracts "company.com" which is valid and business
-		{"email_with_at_in_local", "user@host@company.com", true},
+		// Unquoted '@' in the local part is invalid
+		{"email_with_at_in_local", "user@host@company.com", false},
+
+		// Display names and comments
+		{"display_name", "Jane <jane@example.com>", true},
+		{"quoted_display_name", `"Doe, Jane" <jane@example.com>`, true},
+		{"display_name_free", "Jane <jane@gmail.com>", false},
+		{"comment_in_local", "jane(work)@example.com", true},
+		{"comment_hides_free_domain", "jane@example.com (not jane@gmail.com)", true},
+
+		// Dot-atom rules
+		{"leading_dot_local", ".user@example.com", false},
+		{"trailing_dot_local", "user.@example.com", false},
+		{"consecutive_dots_local", "us..er@example.com", false},
 
 		// Very long emails
 		{"long_local_part", strings.Repeat("a", 64) + "@example.com", true},
+		{"too_long_local_part", strings.Repeat("a", 65) + "@example.com", false},
 		{"long_domain", "user@" + strings.Repeat("sub.", 10) + "example.com", true},
 
 		// Unicode
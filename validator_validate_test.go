@@ -0,0 +1,158 @@
+package workemailvalidator_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	workemailvalidator "github.com/rixlhq/work-email-validator"
+)
+
+// TestValidateWorkEmail tests that every failure maps to its sentinel error.
+func TestValidateWorkEmail(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithBlocklist("blocked.io"))
+
+	tests := []struct {
+		name   string
+		email  string
+		reason error
+	}{
+		{"business", "user@example.com", nil},
+		{"business_display_name", "Jane <jane@example.com>", nil},
+		{"free", "user@gmail.com", workemailvalidator.ErrFreeProvider},
+		{"free_subdomain", "user@mail.gmail.com", workemailvalidator.ErrFreeProvider},
+		{"disposable", "user@temp-mail.org", workemailvalidator.ErrDisposable},
+		{"blocklisted", "user@blocked.io", workemailvalidator.ErrBlocklisted},
+		{"unparsable", "user@@example.com", workemailvalidator.ErrInvalidSyntax},
+		{"invalid_domain", "user@localhost", workemailvalidator.ErrInvalidSyntax},
+		{"idn_failure", "user@xn--55555555.com", workemailvalidator.ErrIDNConversion},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.ValidateWorkEmail(testCase.email)
+
+			if testCase.reason == nil {
+				if err != nil {
+					t.Errorf("ValidateWorkEmail(%q) = %v, want nil", testCase.email, err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, testCase.reason) {
+				t.Errorf("ValidateWorkEmail(%q) = %v, want %v", testCase.email, err, testCase.reason)
+			}
+
+			var validationErr *workemailvalidator.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Reason != testCase.reason || validationErr.Input != testCase.email {
+				t.Errorf("ValidateWorkEmail(%q) = %#v, want *ValidationError with reason %v", testCase.email, err, testCase.reason)
+			}
+
+			// IsWorkEmail falls back to the raw domain when IDN conversion fails
+			if errors.Is(err, workemailvalidator.ErrIDNConversion) {
+				return
+			}
+
+			if validator.IsWorkEmail(testCase.email) {
+				t.Errorf("ValidateWorkEmail(%q) = %v disagrees with IsWorkEmail", testCase.email, err)
+			}
+		})
+	}
+}
+
+// TestValidationErrorDetails tests the wrapped cause and classification carried by the error.
+func TestValidationErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	err := workemailvalidator.ValidateWorkEmail("user@@example.com")
+	if !errors.Is(err, workemailvalidator.ErrInvalidAddress) {
+		t.Errorf("parse failure should wrap ErrInvalidAddress, got %v", err)
+	}
+
+	if want := `"user@@example.com": invalid syntax: invalid email address: missing domain`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	err = workemailvalidator.ValidateWorkEmail("user@x.temp-mail.org")
+
+	var validationErr *workemailvalidator.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateWorkEmail() = %v, want *ValidationError", err)
+	}
+
+	if validationErr.Result.Match != "temp-mail.org" || validationErr.Result.Domain != "x.temp-mail.org" {
+		t.Errorf("Result = %+v, want match on temp-mail.org", validationErr.Result)
+	}
+
+	if want := `"user@x.temp-mail.org": disposable email domain`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	err = workemailvalidator.ValidateWorkEmail("user@xn--55555555.com")
+	if !strings.Contains(err.Error(), "idna") {
+		t.Errorf("IDN failure should carry the conversion error, got %q", err)
+	}
+}
+
+// TestValidateBusinessDomain tests the domain-only validation.
+func TestValidateBusinessDomain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		domain string
+		reason error
+	}{
+		{"example.com", nil},
+		{"  MÜNCHEN.de ", nil},
+		{"gmail.com", workemailvalidator.ErrFreeProvider},
+		{"10minutemail.com", workemailvalidator.ErrDisposable},
+		{"", workemailvalidator.ErrInvalidSyntax},
+		{"xn--zz.com", workemailvalidator.ErrIDNConversion},
+	}
+
+	for _, testCase := range tests {
+		err := workemailvalidator.ValidateBusinessDomain(testCase.domain)
+		if testCase.reason == nil && err != nil || testCase.reason != nil && !errors.Is(err, testCase.reason) {
+			t.Errorf("ValidateBusinessDomain(%q) = %v, want %v", testCase.domain, err, testCase.reason)
+		}
+	}
+}
+
+// TestValidationErrorCategory tests that specific reasons also match the sentinel of the domain's category.
+func TestValidationErrorCategory(t *testing.T) {
+	t.Parallel()
+
+	validator := workemailvalidator.New(workemailvalidator.WithBlocklist("blocked.io"))
+
+	tests := []struct {
+		name     string
+		email    string
+		reason   error
+		category error
+		other    error
+	}{
+		{"blocklisted", "user@blocked.io", workemailvalidator.ErrBlocklisted, workemailvalidator.ErrDisposable, workemailvalidator.ErrFreeProvider},
+		{"disposable", "user@temp-mail.org", workemailvalidator.ErrDisposable, workemailvalidator.ErrDisposable, workemailvalidator.ErrFreeProvider},
+		{"free", "user@gmail.com", workemailvalidator.ErrFreeProvider, workemailvalidator.ErrFreeProvider, workemailvalidator.ErrDisposable},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validator.ValidateWorkEmail(testCase.email)
+			if !errors.Is(err, testCase.reason) || !errors.Is(err, testCase.category) {
+				t.Errorf("ValidateWorkEmail(%q) = %v, want %v and %v", testCase.email, err, testCase.reason, testCase.category)
+			}
+
+			if errors.Is(err, testCase.other) {
+				t.Errorf("ValidateWorkEmail(%q) = %v, should not match %v", testCase.email, err, testCase.other)
+			}
+		})
+	}
+}